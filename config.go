@@ -22,9 +22,11 @@ package zap
 
 import (
 	"errors"
+	"fmt"
 	"sort"
 	"time"
 
+	"go.uber.org/multierr"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -34,12 +36,94 @@ import (
 //
 // If specified, the Sampler will invoke the Hook after each decision.
 //
-// Values configured here are per-second. See zapcore.NewSamplerWithOptions for
-// details.
+// Values configured here are per Tick, which defaults to one second. See
+// zapcore.NewSamplerWithOptions for details.
 type SamplingConfig struct {
-	Initial    int                                           `json:"initial" yaml:"initial"`
-	Thereafter int                                           `json:"thereafter" yaml:"thereafter"`
-	Hook       func(zapcore.Entry, zapcore.SamplingDecision) `json:"-" yaml:"-"`
+	Initial    int `json:"initial" yaml:"initial"`
+	Thereafter int `json:"thereafter" yaml:"thereafter"`
+	// Tick is the interval over which entries are counted. In JSON, it's a
+	// number of nanoseconds; in YAML, it may also be a string like "10s".
+	Tick time.Duration `json:"tick" yaml:"tick"`
+	// Levels overrides Initial and Thereafter for individual levels, for
+	// example to sample debug logs more aggressively, or to never sample
+	// errors.
+	Levels map[zapcore.Level]LevelSamplingConfig `json:"levels" yaml:"levels"`
+	// TraceField, if set, is the key of a field holding a trace or request
+	// ID. Sampling decisions are then made once per ID, so that a sampled
+	// request keeps all of its entries. See zapcore.SamplerTraceField.
+	TraceField string `json:"traceField" yaml:"traceField"`
+	// KeyFields, if set, selects fields whose values are part of the sampling
+	// key along with the level and message, so that entries with different
+	// values are sampled separately. See zapcore.SamplerKeyFields.
+	KeyFields func(zapcore.Field) bool                      `json:"-" yaml:"-"`
+	Hook      func(zapcore.Entry, zapcore.SamplingDecision) `json:"-" yaml:"-"`
+}
+
+// StacktraceConfig sets the frames kept in captured stacktraces. Frames are
+// matched by package prefixes as described by StacktraceOption.
+type StacktraceConfig struct {
+	// SkipPackages drops frames in packages matching these prefixes.
+	SkipPackages []string `json:"skipPackages" yaml:"skipPackages"`
+	// CollapsePackages collapses consecutive frames in packages matching the
+	// same one of these prefixes into the first of them.
+	CollapsePackages []string `json:"collapsePackages" yaml:"collapsePackages"`
+	// MaxDepth, if positive, limits the number of frames kept.
+	MaxDepth int `json:"maxDepth" yaml:"maxDepth"`
+}
+
+// options converts the configuration to options for AddStacktrace.
+func (scfg *StacktraceConfig) options() []StacktraceOption {
+	if scfg == nil {
+		return nil
+	}
+	return []StacktraceOption{
+		StacktraceSkipPackages(scfg.SkipPackages...),
+		StacktraceCollapsePackages(scfg.CollapsePackages...),
+		StacktraceMaxDepth(scfg.MaxDepth),
+	}
+}
+
+// LevelSamplingConfig sets the sampling strategy for a single level.
+type LevelSamplingConfig struct {
+	Initial    int `json:"initial" yaml:"initial"`
+	Thereafter int `json:"thereafter" yaml:"thereafter"`
+	// Disabled turns off sampling at the level, so that every entry is
+	// logged. Initial and Thereafter are ignored.
+	Disabled bool `json:"disabled" yaml:"disabled"`
+}
+
+// samplerOptions converts the configuration to options for
+// zapcore.NewSamplerWithOptions.
+func (scfg *SamplingConfig) samplerOptions() []zapcore.SamplerOption {
+	var opts []zapcore.SamplerOption
+	if scfg.Hook != nil {
+		opts = append(opts, zapcore.SamplerHook(scfg.Hook))
+	}
+	if scfg.KeyFields != nil {
+		opts = append(opts, zapcore.SamplerKeyFields(scfg.KeyFields))
+	}
+	if scfg.TraceField != "" {
+		opts = append(opts, zapcore.SamplerTraceField(scfg.TraceField, 0))
+	}
+
+	var exempt map[zapcore.Level]struct{}
+	for lvl, lcfg := range scfg.Levels {
+		if lcfg.Disabled {
+			if exempt == nil {
+				exempt = make(map[zapcore.Level]struct{})
+			}
+			exempt[lvl] = struct{}{}
+			continue
+		}
+		opts = append(opts, zapcore.SamplerLevel(lvl, lcfg.Initial, lcfg.Thereafter))
+	}
+	if len(exempt) > 0 {
+		opts = append(opts, zapcore.SamplerExempt(LevelEnablerFunc(func(lvl zapcore.Level) bool {
+			_, ok := exempt[lvl]
+			return ok
+		})))
+	}
+	return opts
 }
 
 // Config offers a declarative way to construct a logger. It doesn't do
@@ -47,11 +131,13 @@ type SamplingConfig struct {
 // zapcore.WriteSyncer and zapcore.Core wrappers, but it's a simpler way to
 // toggle common options.
 //
-// Note that Config intentionally supports only the most common options. More
-// unusual logging setups (logging to network connections or message queues,
-// splitting output between multiple files, etc.) are possible, but require
-// direct use of the zapcore package. For sample code, see the package-level
-// BasicConfiguration and AdvancedConfiguration examples.
+// Note that Config intentionally supports only the most common options. Zap
+// can write to files, rotating files, syslog and TCP or UDP peers through
+// OutputPaths (see Open), and to several outputs with different encodings
+// and levels through Outputs, but more unusual logging setups (logging to
+// message queues, etc.) are possible only through direct use of the zapcore
+// package. For sample code, see the package-level BasicConfiguration and
+// AdvancedConfiguration examples.
 //
 // For an example showing runtime log level changes, see the documentation for
 // AtomicLevel.
@@ -60,6 +146,16 @@ type Config struct {
 	// level, so calling Config.Level.SetLevel will atomically change the log
 	// level of all loggers descended from this config.
 	Level AtomicLevel `json:"level" yaml:"level"`
+	// Levels optionally sets levels for individual loggers by name, as built
+	// by Logger.Named. Loggers whose names match none of the keys use Level.
+	// See NamedLevels for how names are matched. Like Level, Levels are
+	// dynamic: calling Config.Levels.SetLevel changes the levels of loggers
+	// descended from this config. NewProductionConfig and
+	// NewDevelopmentConfig initialize Levels; other Configs need
+	// NewNamedLevels before levels can be set. Levels don't store Level, so
+	// on their own, as with Levels.LevelFor, they report InfoLevel for
+	// unmatched names unless a level is set for the empty name.
+	Levels NamedLevels `json:"levels" yaml:"levels"`
 	// Development puts the logger in development mode, which changes the
 	// behavior of DPanicLevel and takes stacktraces more liberally.
 	Development bool `json:"development" yaml:"development"`
@@ -70,11 +166,16 @@ type Config struct {
 	// default, stacktraces are captured for WarnLevel and above logs in
 	// development and ErrorLevel and above in production.
 	DisableStacktrace bool `json:"disableStacktrace" yaml:"disableStacktrace"`
+	// Stacktrace trims the frames of captured stacktraces. A nil
+	// StacktraceConfig keeps every frame.
+	Stacktrace *StacktraceConfig `json:"stacktrace" yaml:"stacktrace"`
 	// Sampling sets a sampling policy. A nil SamplingConfig disables sampling.
 	Sampling *SamplingConfig `json:"sampling" yaml:"sampling"`
-	// Encoding sets the logger's encoding. Valid values are "json" and
-	// "console", as well as any third-party encodings registered via
-	// RegisterEncoder.
+	// Encoding sets the logger's encoding. Valid values are "json",
+	// "console", and "logfmt", as well as any third-party encodings
+	// registered via RegisterEncoder. It may be left empty if every entry
+	// in Outputs sets its own Encoding and OutputPaths and Routing are
+	// unset.
 	Encoding string `json:"encoding" yaml:"encoding"`
 	// EncoderConfig sets options for the chosen encoder. See
 	// zapcore.EncoderConfig for details.
@@ -85,12 +186,67 @@ type Config struct {
 	// ErrorOutputPaths is a list of URLs to write internal logger errors to.
 	// The default is standard error.
 	//
-	// Note that this setting only affects internal errors; for sample code that
-	// sends error-level logs to a different location from info- and debug-level
-	// logs, see the package-level AdvancedConfiguration example.
+	// Note that this setting only affects internal errors; to send error-level
+	// logs to a different location from info- and debug-level logs, use
+	// Outputs, or see the package-level AdvancedConfiguration example.
 	ErrorOutputPaths []string `json:"errorOutputPaths" yaml:"errorOutputPaths"`
 	// InitialFields is a collection of fields to add to the root logger.
 	InitialFields map[string]interface{} `json:"initialFields" yaml:"initialFields"`
+	// Routing optionally sends entries to different outputs based on their
+	// level, logger name, message, and fields. Entries that match no route
+	// are written to OutputPaths.
+	Routing *RoutingConfig `json:"routing" yaml:"routing"`
+	// Outputs optionally lists more outputs, each with its own encoding and
+	// minimum level, such as JSON at InfoLevel to a file alongside console
+	// output to standard error. Every entry enabled by Level is written to
+	// each output whose level it meets, regardless of Routing.
+	Outputs []OutputConfig `json:"outputs" yaml:"outputs"`
+}
+
+// OutputConfig configures one of a Config's Outputs.
+type OutputConfig struct {
+	// Paths is a list of URLs or file paths to write to. See Open for
+	// details.
+	Paths []string `json:"paths" yaml:"paths"`
+	// Encoding sets the output's encoding. It defaults to the Config's
+	// Encoding.
+	Encoding string `json:"encoding" yaml:"encoding"`
+	// EncoderConfig sets options for the encoder. It defaults to the Config's
+	// EncoderConfig.
+	EncoderConfig *zapcore.EncoderConfig `json:"encoderConfig" yaml:"encoderConfig"`
+	// Level is the output's minimum level. Entries must also be enabled by
+	// the Config's Level and Levels. It defaults to DebugLevel, so that the
+	// Config's levels alone apply.
+	Level *zapcore.Level `json:"level" yaml:"level"`
+}
+
+// RoutingConfig routes entries to outputs by rules. See
+// zapcore.NewRoutingCore for details.
+type RoutingConfig struct {
+	// Mode is "first", the default, to write each entry to the first route
+	// that matches it, or "all" to write it to every route that matches it.
+	Mode string `json:"mode" yaml:"mode"`
+	// Routes lists the routes in order.
+	Routes []RouteConfig `json:"routes" yaml:"routes"`
+}
+
+// RouteConfig writes the entries that match all of its criteria to
+// OutputPaths, with the Config's encoding and levels. Criteria that aren't
+// set match all entries.
+type RouteConfig struct {
+	// Level matches entries at this level and above.
+	Level *zapcore.Level `json:"level" yaml:"level"`
+	// LoggerName is a glob pattern, as understood by path.Match, matching
+	// logger names, such as "http.*".
+	LoggerName string `json:"loggerName" yaml:"loggerName"`
+	// MessagePrefix matches messages that start with it.
+	MessagePrefix string `json:"messagePrefix" yaml:"messagePrefix"`
+	// Fields maps field keys to glob patterns that their values must match,
+	// such as {"audit": "*"} to match entries with an audit field.
+	Fields map[string]string `json:"fields" yaml:"fields"`
+	// OutputPaths is a list of URLs or file paths to write matching entries
+	// to. See Open for details.
+	OutputPaths []string `json:"outputPaths" yaml:"outputPaths"`
 }
 
 // NewProductionEncoderConfig returns an opinionated EncoderConfig for
@@ -157,6 +313,7 @@ func NewProductionEncoderConfig() zapcore.EncoderConfig {
 func NewProductionConfig() Config {
 	return Config{
 		Level:       NewAtomicLevelAt(InfoLevel),
+		Levels:      NewNamedLevels(AtomicLevel{}, nil),
 		Development: false,
 		Sampling: &SamplingConfig{
 			Initial:    100,
@@ -227,6 +384,7 @@ func NewDevelopmentEncoderConfig() zapcore.EncoderConfig {
 func NewDevelopmentConfig() Config {
 	return Config{
 		Level:            NewAtomicLevelAt(DebugLevel),
+		Levels:           NewNamedLevels(AtomicLevel{}, nil),
 		Development:      true,
 		Encoding:         "console",
 		EncoderConfig:    NewDevelopmentEncoderConfig(),
@@ -236,64 +394,86 @@ func NewDevelopmentConfig() Config {
 }
 
 // Build constructs a logger from the Config and Options.
+//
+// The returned Logger owns the sinks opened for OutputPaths and
+// ErrorOutputPaths. Call Logger.Close to sync and close them once the Logger
+// is no longer needed.
 func (cfg Config) Build(opts ...Option) (*Logger, error) {
-	enc, err := cfg.buildEncoder()
+	core, errSink, closeSinks, err := cfg.buildCore(openCombined)
 	if err != nil {
 		return nil, err
 	}
 
-	sink, errSink, err := cfg.openSinks()
-	if err != nil {
-		return nil, err
-	}
-
-	if cfg.Level == (AtomicLevel{}) {
-		return nil, errors.New("missing Level")
-	}
-
-	log := New(
-		zapcore.NewCore(enc, sink, cfg.Level),
-		cfg.buildOptions(errSink)...,
-	)
+	log := New(core, cfg.buildOptions(errSink)...)
+	log.onClose(closeSinks)
 	if len(opts) > 0 {
 		log = log.WithOptions(opts...)
 	}
 	return log, nil
 }
 
-func (cfg Config) buildOptions(errSink zapcore.WriteSyncer) []Option {
-	opts := []Option{ErrorOutput(errSink)}
+// buildCore opens the Config's outputs and builds the Core that writes to
+// them, including sampling and initial fields. It returns the sink for
+// ErrorOutputPaths and a function that closes everything it opened. Sinks
+// are opened with open.
+func (cfg Config) buildCore(open sinkOpener) (_ zapcore.Core, errSink zapcore.WriteSyncer, closeSinks func() error, err error) {
+	// The top-level Encoding and EncoderConfig are only needed by
+	// OutputPaths and Routing, so a Config that only uses Outputs, each with
+	// its own Encoding, may leave them unset.
+	topLevel := len(cfg.OutputPaths) > 0 || cfg.Routing != nil || len(cfg.Outputs) == 0
+	var enc zapcore.Encoder
+	if topLevel {
+		if enc, err = cfg.buildEncoder(); err != nil {
+			return nil, nil, nil, err
+		}
+	}
 
-	if cfg.Development {
-		opts = append(opts, Development())
+	if cfg.Level == (AtomicLevel{}) {
+		return nil, nil, nil, errors.New("missing Level")
 	}
 
-	if !cfg.DisableCaller {
-		opts = append(opts, AddCaller())
+	sink, errSink, closeSinks, err := cfg.openSinks(open)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
-	stackLevel := ErrorLevel
-	if cfg.Development {
-		stackLevel = WarnLevel
+	var cores []zapcore.Core
+	if topLevel {
+		core := zapcore.NewCore(enc, sink, cfg.levelEnabler())
+		if cfg.Routing != nil {
+			var closeRoutes func() error
+			core, closeRoutes, err = cfg.buildRoutes(core, open)
+			if err != nil {
+				_ = closeSinks()
+				return nil, nil, nil, err
+			}
+			closeSinks = appendCloser(closeSinks, closeRoutes)
+		}
+		cores = append(cores, core)
 	}
-	if !cfg.DisableStacktrace {
-		opts = append(opts, AddStacktrace(stackLevel))
+	for _, ocfg := range cfg.Outputs {
+		core, closeOutput, err := cfg.buildOutput(ocfg, open)
+		if err != nil {
+			_ = closeSinks()
+			return nil, nil, nil, err
+		}
+		closeSinks = appendCloser(closeSinks, closeOutput)
+		cores = append(cores, core)
 	}
+	core := zapcore.NewTee(cores...)
 
 	if scfg := cfg.Sampling; scfg != nil {
-		opts = append(opts, WrapCore(func(core zapcore.Core) zapcore.Core {
-			var samplerOpts []zapcore.SamplerOption
-			if scfg.Hook != nil {
-				samplerOpts = append(samplerOpts, zapcore.SamplerHook(scfg.Hook))
-			}
-			return zapcore.NewSamplerWithOptions(
-				core,
-				time.Second,
-				cfg.Sampling.Initial,
-				cfg.Sampling.Thereafter,
-				samplerOpts...,
-			)
-		}))
+		tick := scfg.Tick
+		if tick <= 0 {
+			tick = time.Second
+		}
+		core = zapcore.NewSamplerWithOptions(
+			core,
+			tick,
+			scfg.Initial,
+			scfg.Thereafter,
+			scfg.samplerOptions()...,
+		)
 	}
 
 	if len(cfg.InitialFields) > 0 {
@@ -306,23 +486,179 @@ func (cfg Config) buildOptions(errSink zapcore.WriteSyncer) []Option {
 		for _, k := range keys {
 			fs = append(fs, Any(k, cfg.InitialFields[k]))
 		}
-		opts = append(opts, Fields(fs...))
+		core = core.With(fs)
+	}
+
+	return core, errSink, closeSinks, nil
+}
+
+// buildOptions returns the Logger options for the parts of the Config that
+// aren't handled by its Core.
+func (cfg Config) buildOptions(errSink zapcore.WriteSyncer) []Option {
+	opts := []Option{ErrorOutput(errSink)}
+
+	if cfg.Development {
+		opts = append(opts, Development())
+	}
+
+	if !cfg.DisableCaller {
+		opts = append(opts, AddCaller())
+	}
+
+	stackLevel := ErrorLevel
+	if cfg.Development {
+		stackLevel = WarnLevel
+	}
+	if !cfg.DisableStacktrace {
+		opts = append(opts, AddStacktrace(stackLevel, cfg.Stacktrace.options()...))
 	}
 
 	return opts
 }
 
-func (cfg Config) openSinks() (zapcore.WriteSyncer, zapcore.WriteSyncer, error) {
-	sink, closeOut, err := Open(cfg.OutputPaths...)
+// openSinks opens OutputPaths and ErrorOutputPaths, returning a function
+// that closes both.
+func (cfg Config) openSinks(open sinkOpener) (sink, errSink zapcore.WriteSyncer, closeAll func() error, err error) {
+	sink, closeOut, err := open(cfg.OutputPaths)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	errSink, closeErr, err := open(cfg.ErrorOutputPaths)
+	if err != nil {
+		_ = closeOut()
+		return nil, nil, nil, err
+	}
+	closeAll = func() error {
+		return multierr.Append(closeErr(), closeOut())
+	}
+	return sink, errSink, closeAll, nil
+}
+
+// levelEnabler returns the LevelEnabler for the Core built by the Config.
+func (cfg Config) levelEnabler() zapcore.LevelEnabler {
+	if cfg.Levels.isZero() {
+		return cfg.Level
+	}
+	return namedLevelsWithRoot{levels: cfg.Levels, root: cfg.Level}
+}
+
+// buildOutput opens an output from Outputs and builds its Core. It returns
+// a function that closes the output.
+func (cfg Config) buildOutput(ocfg OutputConfig, open sinkOpener) (zapcore.Core, func() error, error) {
+	encoding := cfg.Encoding
+	if ocfg.Encoding != "" {
+		encoding = ocfg.Encoding
+	}
+	encCfg := cfg.EncoderConfig
+	if ocfg.EncoderConfig != nil {
+		encCfg = *ocfg.EncoderConfig
+	}
+	enc, err := newEncoder(encoding, encCfg)
 	if err != nil {
 		return nil, nil, err
 	}
-	errSink, _, err := Open(cfg.ErrorOutputPaths...)
+
+	enab := cfg.levelEnabler()
+	if ocfg.Level != nil {
+		enab = outputLevelEnabler{min: *ocfg.Level, enab: enab}
+	}
+
+	sink, closeSink, err := open(ocfg.Paths)
 	if err != nil {
-		closeOut()
 		return nil, nil, err
 	}
-	return sink, errSink, nil
+	return zapcore.NewCore(enc, sink, enab), closeSink, nil
+}
+
+// outputLevelEnabler applies an output's minimum level on top of the
+// Config's levels, which may change at runtime.
+type outputLevelEnabler struct {
+	min  zapcore.Level
+	enab zapcore.LevelEnabler
+}
+
+var _ zapcore.NamedLevelEnabler = outputLevelEnabler{}
+
+func (e outputLevelEnabler) Enabled(lvl zapcore.Level) bool {
+	return lvl >= e.min && e.enab.Enabled(lvl)
+}
+
+func (e outputLevelEnabler) EnabledFor(name string, lvl zapcore.Level) bool {
+	if lvl < e.min {
+		return false
+	}
+	if named, ok := e.enab.(zapcore.NamedLevelEnabler); ok {
+		return named.EnabledFor(name, lvl)
+	}
+	return e.enab.Enabled(lvl)
+}
+
+func (e outputLevelEnabler) Level() zapcore.Level {
+	if lvl := zapcore.LevelOf(e.enab); lvl > e.min {
+		return lvl
+	}
+	return e.min
+}
+
+// appendCloser returns a function that calls both closers.
+func appendCloser(first, second func() error) func() error {
+	return func() error {
+		return multierr.Append(first(), second())
+	}
+}
+
+// buildRoutes opens the outputs of the routes in cfg.Routing and builds a
+// routing Core, using fallback for entries that match no route. It returns
+// a function that closes the routes' outputs.
+func (cfg Config) buildRoutes(fallback zapcore.Core, open sinkOpener) (_ zapcore.Core, closeAll func() error, err error) {
+	var mode zapcore.RoutingMode
+	switch cfg.Routing.Mode {
+	case "", "first":
+		mode = zapcore.RouteFirstMatch
+	case "all":
+		mode = zapcore.RouteAllMatches
+	default:
+		return nil, nil, fmt.Errorf("unknown routing mode %q", cfg.Routing.Mode)
+	}
+	if len(cfg.OutputPaths) == 0 {
+		fallback = nil
+	}
+
+	var closers []func() error
+	closeAll = func() error {
+		var err error
+		for _, c := range closers {
+			err = multierr.Append(err, c())
+		}
+		return err
+	}
+
+	routes := make([]zapcore.Route, 0, len(cfg.Routing.Routes))
+	for _, rcfg := range cfg.Routing.Routes {
+		enc, err := cfg.buildEncoder()
+		if err != nil {
+			return nil, nil, multierr.Append(err, closeAll())
+		}
+		sink, closeSink, err := open(rcfg.OutputPaths)
+		if err != nil {
+			return nil, nil, multierr.Append(err, closeAll())
+		}
+		closers = append(closers, closeSink)
+
+		rule := zapcore.RouteRule{
+			LoggerName:    rcfg.LoggerName,
+			MessagePrefix: rcfg.MessagePrefix,
+			Fields:        rcfg.Fields,
+		}
+		if rcfg.Level != nil {
+			rule.Level = *rcfg.Level
+		}
+		routes = append(routes, zapcore.Route{
+			Rule: rule,
+			Core: zapcore.NewCore(enc, sink, cfg.levelEnabler()),
+		})
+	}
+	return zapcore.NewRoutingCore(mode, routes, fallback), closeAll, nil
 }
 
 func (cfg Config) buildEncoder() (zapcore.Encoder, error) {
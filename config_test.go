@@ -21,14 +21,20 @@
 package zap
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
+	"go.yaml.in/yaml/v3"
 )
 
 func TestConfig(t *testing.T) {
@@ -85,6 +91,86 @@ func TestConfig(t *testing.T) {
 	}
 }
 
+func TestConfigBuildClose(t *testing.T) {
+	sr := stubSinkRegistry(t)
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out.log")
+
+	cfg := NewProductionConfig()
+	cfg.OutputPaths = []string{out}
+	cfg.ErrorOutputPaths = []string{filepath.Join(dir, "err.log")}
+
+	logger, err := cfg.Build(Fields(String("k", "v")))
+	require.NoError(t, err, "Unexpected error constructing logger.")
+	assert.Len(t, sr.reopeners, 2, "Expected output and error output files to be open.")
+
+	logger.Named("child").Info("hello")
+	require.NoError(t, logger.Close(), "Unexpected error closing logger.")
+	assert.Empty(t, sr.reopeners, "Expected Close to close every file opened by Build.")
+
+	contents, err := os.ReadFile(out)
+	require.NoError(t, err, "Couldn't read log contents from temp file.")
+	assert.Contains(t, string(contents), `"msg":"hello"`, "Expected entry to be written before closing.")
+}
+
+func TestConfigLevels(t *testing.T) {
+	logOut := filepath.Join(t.TempDir(), "test.log")
+
+	var cfg Config
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"level": "info",
+		"levels": {"db": "warn", "db.pool": "debug"},
+		"encoding": "console",
+		"encoderConfig": {"messageKey": "M", "nameKey": "N"}
+	}`), &cfg), "Failed to unmarshal config.")
+	cfg.OutputPaths = []string{logOut}
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+	defer logger.Close()
+
+	logger.Debug("root debug")
+	logger.Info("root info")
+	logger.Named("db").Info("db info")
+	logger.Named("db").Named("pool").Debug("pool debug")
+
+	// Levels set at runtime through the Config apply to the built logger.
+	cfg.Levels.SetLevel("db", InfoLevel)
+	logger.Named("db").Info("db info again")
+
+	contents, err := os.ReadFile(logOut)
+	require.NoError(t, err, "Couldn't read log contents from temp file.")
+	assert.Equal(t, "root info\ndb.pool\tpool debug\ndb\tdb info again\n", string(contents), "Unexpected log output.")
+}
+
+func TestConfigLevelsWithSamplingKeyFields(t *testing.T) {
+	logOut := filepath.Join(t.TempDir(), "test.log")
+	cfg := Config{
+		Level:    NewAtomicLevelAt(DebugLevel),
+		Levels:   NewNamedLevels(AtomicLevel{}, map[string]zapcore.Level{"db": WarnLevel}),
+		Encoding: "console",
+		Sampling: &SamplingConfig{
+			Initial:   100,
+			KeyFields: func(f zapcore.Field) bool { return f.Key == "tenant" },
+		},
+		EncoderConfig: zapcore.EncoderConfig{MessageKey: "M"},
+		OutputPaths:   []string{logOut},
+	}
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+	defer logger.Close()
+
+	logger.Named("db").Info("db info", String("tenant", "a"))
+	logger.Named("db").Warn("db warn", String("tenant", "a"))
+	logger.Info("root info", String("tenant", "a"))
+
+	contents, err := os.ReadFile(logOut)
+	require.NoError(t, err, "Couldn't read log contents from temp file.")
+	assert.Equal(t, "db warn\t{\"tenant\": \"a\"}\nroot info\t{\"tenant\": \"a\"}\n", string(contents),
+		"Expected per-logger levels to apply when sampling by key fields.")
+}
+
 func TestConfigWithInvalidPaths(t *testing.T) {
 	tests := []struct {
 		desc      string
@@ -201,3 +287,397 @@ func TestConfigWithSamplingHook(t *testing.T) {
 	assert.Equal(t, int64(expectDropped), dcount.Load())
 	assert.Equal(t, int64(expectSampled), scount.Load())
 }
+
+func TestConfigSamplingPolicy(t *testing.T) {
+	const input = `{
+		"initial": 1,
+		"thereafter": 0,
+		"tick": 60000000000,
+		"levels": {
+			"debug": {"initial": 2, "thereafter": 0},
+			"error": {"disabled": true}
+		}
+	}`
+	var scfg SamplingConfig
+	require.NoError(t, json.Unmarshal([]byte(input), &scfg), "Failed to unmarshal sampling config.")
+	assert.Equal(t, time.Minute, scfg.Tick, "Unexpected tick.")
+	assert.Equal(t, map[zapcore.Level]LevelSamplingConfig{
+		DebugLevel: {Initial: 2},
+		ErrorLevel: {Disabled: true},
+	}, scfg.Levels, "Unexpected level overrides.")
+	scfg.KeyFields = func(f zapcore.Field) bool { return f.Key == "tenant" }
+
+	cfg := NewProductionConfig()
+	cfg.Level = NewAtomicLevelAt(DebugLevel)
+	cfg.Sampling = &scfg
+	logOut := filepath.Join(t.TempDir(), "test.log")
+	cfg.OutputPaths = []string{logOut}
+	cfg.EncoderConfig.TimeKey = ""
+	cfg.DisableCaller = true
+	cfg.DisableStacktrace = true
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+
+	for i := 0; i < 5; i++ {
+		logger.Debug("debug")
+		logger.Info("info", String("tenant", "a"))
+		logger.Info("info", String("tenant", "b"))
+		logger.Error("error")
+	}
+	require.NoError(t, logger.Sync(), "Unexpected error syncing logger.")
+
+	contents, err := os.ReadFile(logOut)
+	require.NoError(t, err, "Couldn't read log contents from temp file.")
+	assert.Equal(t, 2, strings.Count(string(contents), `"msg":"debug"`), "Unexpected number of debug logs.")
+	assert.Equal(t, 1, strings.Count(string(contents), `"tenant":"a"`), "Unexpected number of logs for tenant a.")
+	assert.Equal(t, 1, strings.Count(string(contents), `"tenant":"b"`), "Unexpected number of logs for tenant b.")
+	assert.Equal(t, 5, strings.Count(string(contents), `"msg":"error"`), "Expected errors not to be sampled.")
+}
+
+func TestConfigSamplingPolicyYAML(t *testing.T) {
+	const input = `
+initial: 10
+thereafter: 10
+tick: 10s
+levels:
+  info:
+    initial: 5
+    thereafter: 50
+`
+	var scfg SamplingConfig
+	require.NoError(t, yaml.Unmarshal([]byte(input), &scfg), "Failed to unmarshal sampling config.")
+	assert.Equal(t, 10*time.Second, scfg.Tick, "Unexpected tick.")
+	assert.Equal(t, map[zapcore.Level]LevelSamplingConfig{
+		InfoLevel: {Initial: 5, Thereafter: 50},
+	}, scfg.Levels, "Unexpected level overrides.")
+}
+
+func TestConfigSamplingTraceField(t *testing.T) {
+	cfg := NewProductionConfig()
+	cfg.Sampling.Initial = 1
+	cfg.Sampling.Thereafter = 0
+	cfg.Sampling.TraceField = "trace_id"
+	logOut := filepath.Join(t.TempDir(), "test.log")
+	cfg.OutputPaths = []string{logOut}
+	cfg.EncoderConfig.TimeKey = ""
+
+	spanContext := func(ctx context.Context) (TraceContext, bool) {
+		id, ok := ctx.Value(traceIDKey{}).(byte)
+		return TraceContext{TraceID: [16]byte{15: id}, SpanID: [8]byte{7: 1}}, ok
+	}
+	logger, err := cfg.Build(WithContextExtractor(TraceFields(spanContext, TraceKeys{})))
+	require.NoError(t, err, "Unexpected error constructing logger.")
+
+	for id := byte(1); id <= 3; id++ {
+		ctxLogger := logger.Ctx(context.WithValue(context.Background(), traceIDKey{}, id))
+		for i := 0; i < 3; i++ {
+			ctxLogger.Info("handled request")
+		}
+	}
+	require.NoError(t, logger.Sync(), "Unexpected error syncing logger.")
+
+	contents, err := os.ReadFile(logOut)
+	require.NoError(t, err, "Couldn't read log contents from temp file.")
+	assert.Equal(t, 3, strings.Count(string(contents), `"trace_id":"00000000000000000000000000000001"`),
+		"Expected every entry of the first request.")
+	assert.Equal(t, 3, strings.Count(string(contents), "handled request"), "Expected other requests to be dropped.")
+}
+
+type traceIDKey struct{}
+
+func TestConfigRouting(t *testing.T) {
+	dir := t.TempDir()
+	mainOut := filepath.Join(dir, "main.log")
+	auditOut := filepath.Join(dir, "audit.log")
+	httpOut := filepath.Join(dir, "http.log")
+
+	const input = `
+level: debug
+encoding: json
+encoderConfig:
+  messageKey: msg
+  nameKey: logger
+outputPaths: [%q]
+routing:
+  mode: all
+  routes:
+    - fields: {audit: "*"}
+      outputPaths: [%q]
+    - loggerName: "http.*"
+      level: warn
+      outputPaths: [%q]
+`
+	var cfg Config
+	require.NoError(t, yaml.Unmarshal([]byte(fmt.Sprintf(input, mainOut, auditOut, httpOut)), &cfg),
+		"Failed to unmarshal config.")
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+	logger.Info("startup")
+	logger.Info("login", Bool("audit", true))
+	logger.Named("http.server").Info("request handled")
+	logger.Named("http.server").Warn("slow request", Bool("audit", true))
+	require.NoError(t, logger.Close(), "Unexpected error closing logger.")
+
+	read := func(path string) string {
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err, "Couldn't read log contents.")
+		return string(contents)
+	}
+	assert.Equal(t,
+		`{"msg":"startup"}`+"\n"+`{"logger":"http.server","msg":"request handled"}`+"\n",
+		read(mainOut), "Expected unmatched entries in the main output.")
+	assert.Equal(t,
+		`{"msg":"login","audit":true}`+"\n"+`{"logger":"http.server","msg":"slow request","audit":true}`+"\n",
+		read(auditOut), "Unexpected audit output.")
+	assert.Equal(t,
+		`{"logger":"http.server","msg":"slow request","audit":true}`+"\n",
+		read(httpOut), "Unexpected HTTP output.")
+}
+
+func TestConfigRoutingFieldsWithLevels(t *testing.T) {
+	dir := t.TempDir()
+	mainOut := filepath.Join(dir, "main.log")
+	auditOut := filepath.Join(dir, "audit.log")
+
+	cfg := Config{
+		Level:         NewAtomicLevelAt(DebugLevel),
+		Levels:        NewNamedLevels(AtomicLevel{}, map[string]zapcore.Level{"db": WarnLevel}),
+		Encoding:      "console",
+		EncoderConfig: zapcore.EncoderConfig{MessageKey: "M"},
+		OutputPaths:   []string{mainOut},
+		Routing: &RoutingConfig{Routes: []RouteConfig{{
+			Fields:      map[string]string{"audit": "*"},
+			OutputPaths: []string{auditOut},
+		}}},
+	}
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+	logger.Named("db").Info("db info", Bool("audit", true))
+	logger.Named("db").Info("db info")
+	logger.Named("db").Warn("db warn", Bool("audit", true))
+	require.NoError(t, logger.Close(), "Unexpected error closing logger.")
+
+	read := func(path string) string {
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err, "Couldn't read log contents.")
+		return string(contents)
+	}
+	assert.Empty(t, read(mainOut), "Expected per-logger levels to apply to the main output.")
+	assert.Equal(t, "db warn\t{\"audit\": true}\n", read(auditOut),
+		"Expected per-logger levels to apply to routes.")
+}
+
+func TestConfigRoutingErrors(t *testing.T) {
+	cfg := NewProductionConfig()
+	cfg.OutputPaths = []string{filepath.Join(t.TempDir(), "main.log")}
+
+	cfg.Routing = &RoutingConfig{Mode: "some"}
+	_, err := cfg.Build()
+	assert.ErrorContains(t, err, `unknown routing mode "some"`, "Expected an error for an unknown mode.")
+
+	cfg.Routing = &RoutingConfig{Routes: []RouteConfig{{OutputPaths: []string{"unknown://sink"}}}}
+	_, err = cfg.Build()
+	assert.ErrorContains(t, err, "open sink", "Expected an error for an invalid output.")
+}
+
+func TestConfigOutputs(t *testing.T) {
+	dir := t.TempDir()
+	consoleOut := filepath.Join(dir, "console.log")
+	jsonOut := filepath.Join(dir, "json.log")
+	errorsOut := filepath.Join(dir, "errors.log")
+
+	const input = `
+level: debug
+levels:
+  noisy: warn
+encoding: console
+encoderConfig:
+  messageKey: M
+  levelKey: L
+  levelEncoder: capital
+outputPaths: [%q]
+outputs:
+  - paths: [%q]
+    encoding: json
+    encoderConfig:
+      messageKey: msg
+      levelKey: level
+      levelEncoder: lowercase
+    level: info
+  - paths: [%q]
+    level: error
+`
+	var cfg Config
+	require.NoError(t, yaml.Unmarshal([]byte(fmt.Sprintf(input, consoleOut, jsonOut, errorsOut)), &cfg),
+		"Failed to unmarshal config.")
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+	logger.Debug("debug")
+	logger.Info("info", Int("n", 1))
+	logger.Named("noisy").Info("suppressed")
+	logger.Error("error")
+
+	// Lowering the Config's level doesn't lower the outputs' levels.
+	cfg.Level.SetLevel(ErrorLevel)
+	logger.Warn("warn")
+	cfg.Level.SetLevel(DebugLevel)
+	logger.Debug("debug again")
+	require.NoError(t, logger.Close(), "Unexpected error closing logger.")
+
+	read := func(path string) string {
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err, "Couldn't read log contents.")
+		return string(contents)
+	}
+	assert.Equal(t, "DEBUG\tdebug\nINFO\tinfo\t{\"n\": 1}\nERROR\terror\nDEBUG\tdebug again\n", read(consoleOut),
+		"Unexpected console output.")
+	assert.Equal(t, `{"level":"info","msg":"info","n":1}`+"\n"+`{"level":"error","msg":"error"}`+"\n", read(jsonOut),
+		"Unexpected JSON output.")
+	assert.Equal(t, "ERROR\terror\n", read(errorsOut), "Expected the Config's encoding by default.")
+}
+
+func TestConfigOutputsOnly(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out.log")
+	cfg := NewProductionConfig()
+	cfg.OutputPaths = nil
+	cfg.EncoderConfig.TimeKey = ""
+	cfg.DisableCaller = true
+	cfg.Outputs = []OutputConfig{{Paths: []string{out}}}
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+	logger.Info("hello")
+	require.NoError(t, logger.Close(), "Unexpected error closing logger.")
+
+	contents, err := os.ReadFile(out)
+	require.NoError(t, err, "Couldn't read log contents.")
+	assert.Equal(t, `{"level":"info","msg":"hello"}`+"\n", string(contents), "Unexpected output.")
+}
+
+func TestConfigOutputsWithoutTopLevelEncoding(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out.log")
+	cfg := Config{
+		Level: NewAtomicLevelAt(InfoLevel),
+		Outputs: []OutputConfig{{
+			Paths:         []string{out},
+			Encoding:      "console",
+			EncoderConfig: &zapcore.EncoderConfig{MessageKey: "M"},
+		}},
+	}
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Expected a Config with only Outputs not to need a top-level Encoding.")
+	logger.Info("hello")
+	require.NoError(t, logger.Close(), "Unexpected error closing logger.")
+
+	contents, err := os.ReadFile(out)
+	require.NoError(t, err, "Couldn't read log contents.")
+	assert.Equal(t, "hello\n", string(contents), "Unexpected output.")
+
+	cfg.OutputPaths = []string{out}
+	_, err = cfg.Build()
+	assert.Error(t, err, "Expected OutputPaths to need a top-level Encoding.")
+}
+
+func TestConfigOutputsErrors(t *testing.T) {
+	cfg := NewProductionConfig()
+	cfg.OutputPaths = []string{filepath.Join(t.TempDir(), "main.log")}
+
+	cfg.Outputs = []OutputConfig{{Paths: []string{"stderr"}, Encoding: "unknown"}}
+	_, err := cfg.Build()
+	assert.ErrorContains(t, err, `no encoder registered for name "unknown"`, "Expected an error for an unknown encoding.")
+
+	cfg.Outputs = []OutputConfig{{Paths: []string{"unknown://sink"}}}
+	_, err = cfg.Build()
+	assert.ErrorContains(t, err, "open sink", "Expected an error for an invalid output.")
+}
+
+func TestConfigStacktrace(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out.log")
+
+	const input = `
+level: info
+encoding: json
+encoderConfig:
+  messageKey: msg
+  stacktraceKey: stacktrace
+  stacktraceEncoder: frames
+outputPaths: [%q]
+stacktrace:
+  skipPackages: ["testing."]
+  maxDepth: 1
+`
+	var cfg Config
+	require.NoError(t, yaml.Unmarshal([]byte(fmt.Sprintf(input, out)), &cfg), "Failed to unmarshal config.")
+	assert.Equal(t, &StacktraceConfig{SkipPackages: []string{"testing."}, MaxDepth: 1}, cfg.Stacktrace,
+		"Unexpected stacktrace config.")
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+	func() {
+		logger.Error("failed")
+	}()
+	require.NoError(t, logger.Close(), "Unexpected error closing logger.")
+
+	contents, err := os.ReadFile(out)
+	require.NoError(t, err, "Couldn't read log contents.")
+	var entry struct {
+		Stacktrace []map[string]interface{} `json:"stacktrace"`
+	}
+	require.NoError(t, json.Unmarshal(contents, &entry), "Expected a JSON entry: %s", contents)
+	require.Len(t, entry.Stacktrace, 1, "Expected the stack trace to be limited to one frame.")
+	assert.Equal(t, "go.uber.org/zap.TestConfigStacktrace.func1", entry.Stacktrace[0]["function"], "Unexpected frame.")
+}
+
+func TestConfigBuildTwiceWithLevels(t *testing.T) {
+	for _, newConfig := range []func() Config{NewProductionConfig, NewDevelopmentConfig} {
+		cfg := newConfig()
+		cfg.OutputPaths = []string{filepath.Join(t.TempDir(), "out.log")}
+
+		first, err := cfg.Build()
+		require.NoError(t, err, "Unexpected error constructing logger.")
+		defer first.Close()
+
+		cfg.Level = NewAtomicLevelAt(ErrorLevel)
+		second, err := cfg.Build()
+		require.NoError(t, err, "Unexpected error constructing logger.")
+		defer second.Close()
+
+		assert.False(t, second.Core().Enabled(InfoLevel), "Expected the second Build to use the new Level.")
+		assert.True(t, second.Core().Enabled(ErrorLevel), "Expected the second Build to use the new Level.")
+		assert.True(t, first.Core().Enabled(InfoLevel), "Expected the first Build to keep its Level.")
+
+		cfg.Levels.SetLevel("db", DebugLevel)
+		assert.True(t, second.Named("db").Core().Enabled(DebugLevel), "Expected shared Levels to apply.")
+	}
+}
+
+func TestConfigDefaultLevels(t *testing.T) {
+	for _, newConfig := range []func() Config{NewProductionConfig, NewDevelopmentConfig} {
+		out := filepath.Join(t.TempDir(), "out.log")
+		cfg := newConfig()
+		cfg.Level = NewAtomicLevelAt(WarnLevel)
+		cfg.Encoding = "json"
+		cfg.EncoderConfig = zapcore.EncoderConfig{MessageKey: "msg"}
+		cfg.OutputPaths = []string{out}
+		require.NotPanics(t, func() { cfg.Levels.SetLevel("db", DebugLevel) },
+			"Expected levels to be settable on a default Config.")
+
+		logger, err := cfg.Build()
+		require.NoError(t, err, "Unexpected error constructing logger.")
+		logger.Info("not logged")
+		logger.Warn("warn")
+		logger.Named("db").Debug("db debug")
+		cfg.Levels.SetLevel("api", InfoLevel)
+		logger.Named("api").Info("api info")
+		require.NoError(t, logger.Close(), "Unexpected error closing logger.")
+
+		contents, err := os.ReadFile(out)
+		require.NoError(t, err, "Couldn't read log contents.")
+		assert.Equal(t, `{"msg":"warn"}`+"\n"+`{"msg":"db debug"}`+"\n"+`{"msg":"api info"}`+"\n", string(contents),
+			"Unexpected output.")
+	}
+}
@@ -0,0 +1,425 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/internal"
+	"go.uber.org/zap/zapcore"
+)
+
+// WatchOption configures WatchConfig.
+type WatchOption interface {
+	apply(*configWatcher)
+}
+
+type watchOptionFunc func(*configWatcher)
+
+func (f watchOptionFunc) apply(w *configWatcher) {
+	f(w)
+}
+
+// WatchInterval sets how often the file is checked for changes. It defaults
+// to one second.
+func WatchInterval(d time.Duration) WatchOption {
+	return watchOptionFunc(func(w *configWatcher) {
+		w.interval = d
+	})
+}
+
+// WatchDecoder sets the function that decodes the file into a Config. It
+// defaults to json.Unmarshal. Pass the Unmarshal function of a YAML package,
+// such as go.yaml.in/yaml/v3, to read YAML files.
+func WatchDecoder(unmarshal func(data []byte, v interface{}) error) WatchOption {
+	return watchOptionFunc(func(w *configWatcher) {
+		w.unmarshal = unmarshal
+	})
+}
+
+// WatchClock sets the Clock whose tickers schedule the checks. It defaults
+// to zapcore.DefaultClock.
+func WatchClock(clock zapcore.Clock) WatchOption {
+	return watchOptionFunc(func(w *configWatcher) {
+		w.clock = clock
+	})
+}
+
+// WatchConfig builds a Logger from the Config in the JSON file at path, then
+// watches the file and reconfigures the Logger whenever its contents change.
+// Use WatchDecoder to read other formats, such as YAML. Settings missing
+// from the file take their values from NewProductionConfig.
+//
+// A change replaces the Logger's level, encoding, outputs, sampling, and
+// initial fields at once. Entries written during the change go either to the
+// old outputs or to the new ones, in full. Outputs whose URLs or paths are
+// unchanged stay open and are shared with the new configuration, so that
+// rotating and network sinks aren't restarted. Once nothing writes to them,
+// the old outputs are synced and the ones removed from the file are closed.
+// Development,
+// DisableCaller, DisableStacktrace, and Stacktrace are only read when the
+// Logger is built.
+//
+// The file is checked every second by default. After each change, onChange,
+// if not nil, is called with the new Config, or with an error if the file
+// couldn't be read or the Config couldn't be built; the Logger keeps its
+// current configuration in that case. If the new Config is in use but
+// closing the old outputs failed, onChange gets both the Config and the
+// error. Errors are reported once until the file changes again.
+//
+// Close the returned Logger to stop watching and to close its outputs.
+//
+//	logger, err := zap.WatchConfig("/etc/myapp/logging.yaml", nil, zap.WatchDecoder(yaml.Unmarshal))
+//	if err != nil {
+//		return err
+//	}
+//	defer logger.Close()
+func WatchConfig(path string, onChange func(Config, error), opts ...WatchOption) (*Logger, error) {
+	w := &configWatcher{
+		path:      path,
+		onChange:  onChange,
+		unmarshal: json.Unmarshal,
+		interval:  time.Second,
+		clock:     zapcore.DefaultClock,
+		state:     &reloadState{},
+		sinks:     &sinkCache{sinks: make(map[string]*sharedSink)},
+	}
+	for _, opt := range opts {
+		opt.apply(w)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, _, err := w.load(data)
+	if err != nil {
+		return nil, err
+	}
+
+	log := New(&reloadableCore{s: w.state}, cfg.buildOptions(reloadableErrorOutput{w.state})...)
+	log.onClose(w.state.close)
+	log.onClose(w.start())
+	return log, nil
+}
+
+// configWatcher reloads a Config file when it changes.
+type configWatcher struct {
+	path      string
+	onChange  func(Config, error)
+	unmarshal func(data []byte, v interface{}) error
+	interval  time.Duration
+	clock     zapcore.Clock
+	state     *reloadState
+	sinks     *sinkCache
+
+	last    []byte // contents of the file last loaded
+	lastErr string // last error reported, if the file hasn't changed since
+}
+
+// start checks the file on every tick until the returned function is
+// called.
+func (w *configWatcher) start() (stop func() error) {
+	ticker := w.clock.NewTicker(w.interval)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-ticker.C:
+				w.poll()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() error {
+		ticker.Stop()
+		close(done)
+		<-stopped
+		return nil
+	}
+}
+
+func (w *configWatcher) poll() {
+	data, err := os.ReadFile(w.path)
+	if err == nil {
+		if bytes.Equal(data, w.last) {
+			return
+		}
+		cfg, loaded, loadErr := w.load(data)
+		if loaded {
+			w.lastErr = ""
+			if w.onChange != nil {
+				w.onChange(cfg, loadErr)
+			}
+			return
+		}
+		err = loadErr
+		// Don't retry a bad file until it changes.
+		w.last = data
+	}
+
+	if err.Error() == w.lastErr {
+		return
+	}
+	w.lastErr = err.Error()
+	if w.onChange != nil {
+		w.onChange(Config{}, err)
+	}
+}
+
+// load builds the Config in data and swaps it in, reporting whether it's
+// now in use. It may return an error even then, if closing the previous
+// outputs failed.
+func (w *configWatcher) load(data []byte) (_ Config, loaded bool, _ error) {
+	cfg := NewProductionConfig()
+	if err := w.unmarshal(data, &cfg); err != nil {
+		return Config{}, false, fmt.Errorf("parse %v: %w", w.path, err)
+	}
+
+	core, errSink, closeSinks, err := cfg.buildCore(w.sinks.openCombined)
+	if err != nil {
+		return Config{}, false, fmt.Errorf("build %v: %w", w.path, err)
+	}
+	w.last = data
+	if err := w.state.swap(&reloadGen{core: core, errSink: errSink, close: closeSinks}); err != nil {
+		return cfg, true, fmt.Errorf("close previous outputs: %w", err)
+	}
+	return cfg, true, nil
+}
+
+// sinkCache shares open sinks between the generations of a reloadable Core,
+// keyed by their URL or path. A sink is closed once no generation uses it.
+type sinkCache struct {
+	mu    sync.Mutex
+	sinks map[string]*sharedSink
+}
+
+type sharedSink struct {
+	Sink
+
+	refs int
+}
+
+// openCombined is like the package-level openCombined, but reuses the sinks
+// that are already open.
+func (c *sinkCache) openCombined(paths []string) (zapcore.WriteSyncer, func() error, error) {
+	writers := make([]zapcore.WriteSyncer, 0, len(paths))
+	acquired := make([]string, 0, len(paths))
+	releaseAll := func() error {
+		var err error
+		for _, path := range acquired {
+			err = multierr.Append(err, c.release(path))
+		}
+		return err
+	}
+
+	var openErr error
+	for _, path := range paths {
+		sink, err := c.acquire(path)
+		if err != nil {
+			openErr = multierr.Append(openErr, fmt.Errorf("open sink %q: %w", path, err))
+			continue
+		}
+		writers = append(writers, sink)
+		acquired = append(acquired, path)
+	}
+	if openErr != nil {
+		_ = releaseAll()
+		return nil, nil, openErr
+	}
+	return CombineWriteSyncers(writers...), releaseAll, nil
+}
+
+// acquire returns the open sink for path, opening it if needed.
+func (c *sinkCache) acquire(path string) (Sink, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if s, ok := c.sinks[path]; ok {
+		s.refs++
+		return s.Sink, nil
+	}
+	sink, err := _sinkRegistry.newSink(path)
+	if err != nil {
+		return nil, err
+	}
+	c.sinks[path] = &sharedSink{Sink: sink, refs: 1}
+	return sink, nil
+}
+
+// release closes the sink for path once nothing else uses it.
+func (c *sinkCache) release(path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.sinks[path]
+	if s.refs--; s.refs > 0 {
+		return nil
+	}
+	delete(c.sinks, path)
+	return s.Close()
+}
+
+// reloadState holds the current configuration of a reloadable Core.
+type reloadState struct {
+	// Held for reading while writing to the current generation, and for
+	// writing while replacing it, so that the old generation isn't closed
+	// under an in-flight entry.
+	mu  sync.RWMutex
+	gen atomic.Pointer[reloadGen]
+}
+
+// reloadGen is one configuration of a reloadable Core.
+type reloadGen struct {
+	core    zapcore.Core
+	errSink zapcore.WriteSyncer
+	close   func() error
+}
+
+// swap makes gen the current generation, then syncs and closes the previous
+// one.
+func (s *reloadState) swap(gen *reloadGen) error {
+	s.mu.Lock()
+	old := s.gen.Swap(gen)
+	s.mu.Unlock()
+
+	if old == nil {
+		return nil
+	}
+	return multierr.Append(old.core.Sync(), old.close())
+}
+
+// close closes the current generation's outputs.
+func (s *reloadState) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.gen.Load().close()
+}
+
+// reloadableCore is a Core that delegates to the current generation of a
+// reloadState.
+type reloadableCore struct {
+	s      *reloadState
+	fields []zapcore.Field
+
+	// Caches the current generation's Core with fields applied.
+	derived atomic.Pointer[derivedCore]
+}
+
+type derivedCore struct {
+	gen  *reloadGen
+	core zapcore.Core
+}
+
+var (
+	_ zapcore.Core            = (*reloadableCore)(nil)
+	_ internal.LeveledEnabler = (*reloadableCore)(nil)
+	_ zapcore.WriteSyncer     = reloadableErrorOutput{}
+)
+
+func (c *reloadableCore) Enabled(lvl zapcore.Level) bool {
+	return c.s.gen.Load().core.Enabled(lvl)
+}
+
+func (c *reloadableCore) Level() zapcore.Level {
+	return zapcore.LevelOf(c.s.gen.Load().core)
+}
+
+func (c *reloadableCore) With(fields []zapcore.Field) zapcore.Core {
+	return &reloadableCore{
+		s:      c.s,
+		fields: append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *reloadableCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	// The current generation decides in Write, so that the entry isn't
+	// written to a generation that has since been closed.
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *reloadableCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	c.s.mu.RLock()
+	defer c.s.mu.RUnlock()
+
+	gen := c.s.gen.Load()
+	if ce := c.current(gen).Check(ent, nil); ce != nil {
+		// CheckedEntry reports write errors to its ErrorOutput.
+		ce.ErrorOutput = gen.errSink
+		ce.Write(fields...)
+	}
+	return nil
+}
+
+func (c *reloadableCore) Sync() error {
+	c.s.mu.RLock()
+	defer c.s.mu.RUnlock()
+
+	return c.s.gen.Load().core.Sync()
+}
+
+// current returns gen's Core with c's fields applied.
+func (c *reloadableCore) current(gen *reloadGen) zapcore.Core {
+	if len(c.fields) == 0 {
+		return gen.core
+	}
+	if d := c.derived.Load(); d != nil && d.gen == gen {
+		return d.core
+	}
+	core := gen.core.With(c.fields)
+	c.derived.Store(&derivedCore{gen: gen, core: core})
+	return core
+}
+
+// reloadableErrorOutput writes to the current generation's ErrorOutputPaths.
+type reloadableErrorOutput struct {
+	s *reloadState
+}
+
+func (o reloadableErrorOutput) Write(p []byte) (int, error) {
+	o.s.mu.RLock()
+	defer o.s.mu.RUnlock()
+
+	return o.s.gen.Load().errSink.Write(p)
+}
+
+func (o reloadableErrorOutput) Sync() error {
+	o.s.mu.RLock()
+	defer o.s.mu.RUnlock()
+
+	return o.s.gen.Load().errSink.Sync()
+}
@@ -0,0 +1,410 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap/internal/ztest"
+	"go.uber.org/zap/zapcore"
+	"go.yaml.in/yaml/v3"
+)
+
+// trackedFile is a file Sink that records whether it was closed.
+type trackedFile struct {
+	*os.File
+
+	closed bool
+}
+
+func (f *trackedFile) Close() error {
+	f.closed = true
+	return f.File.Close()
+}
+
+// stubTrackedSinks registers the "tracked" scheme, which opens files like
+// the default scheme and returns them by path.
+func stubTrackedSinks(t testing.TB) func(path string) []*trackedFile {
+	var (
+		mu    sync.Mutex
+		files = make(map[string][]*trackedFile)
+	)
+	require.NoError(t, stubSinkRegistry(t).RegisterSink("tracked", func(u *url.URL) (Sink, error) {
+		f, err := os.OpenFile(u.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o666)
+		if err != nil {
+			return nil, err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		tf := &trackedFile{File: f}
+		files[u.Path] = append(files[u.Path], tf)
+		return tf, nil
+	}), "Failed to register sink.")
+
+	return func(path string) []*trackedFile {
+		mu.Lock()
+		defer mu.Unlock()
+		return files[path]
+	}
+}
+
+// configFile writes a Config file, returning a function that replaces its
+// contents.
+func configFile(t testing.TB, contents string) (path string, rewrite func(string)) {
+	path = filepath.Join(t.TempDir(), "logging.yaml")
+	rewrite = func(contents string) {
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o666), "Failed to write config file.")
+	}
+	rewrite(contents)
+	return path, rewrite
+}
+
+// watchYAML is WatchConfig for the YAML files written by configFile.
+func watchYAML(path string, onChange func(Config, error), opts ...WatchOption) (*Logger, error) {
+	return WatchConfig(path, onChange, append([]WatchOption{WatchDecoder(yaml.Unmarshal)}, opts...)...)
+}
+
+// watchChanges returns an onChange function for WatchConfig and a channel
+// that receives its arguments.
+func watchChanges() (func(Config, error), <-chan error) {
+	changes := make(chan error, 1)
+	return func(_ Config, err error) { changes <- err }, changes
+}
+
+func readLines(t testing.TB, path string) []string {
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err, "Couldn't read log contents.")
+	if len(contents) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(contents), "\n"), "\n")
+}
+
+func TestWatchConfig(t *testing.T) {
+	tracked := stubTrackedSinks(t)
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.log")
+	second := filepath.Join(dir, "second.log")
+
+	path, rewrite := configFile(t, fmt.Sprintf(`
+level: info
+disableCaller: true
+encoding: json
+encoderConfig: {messageKey: msg, levelKey: "", timeKey: ""}
+outputPaths: ["tracked://%v"]
+sampling:
+  initial: 1
+  thereafter: 0
+initialFields:
+  gen: 1
+`, first))
+
+	clock := ztest.NewMockClock()
+	onChange, changes := watchChanges()
+	logger, err := watchYAML(path, onChange, WatchClock(clock), WatchInterval(time.Second))
+	require.NoError(t, err, "Unexpected error watching config.")
+	named := logger.Named("named").With(String("k", "v"))
+
+	logger.Debug("debug")
+	for i := 0; i < 3; i++ {
+		logger.Info("sampled")
+	}
+	named.Info("with fields")
+
+	// Nothing happens until the file changes.
+	clock.Add(time.Second)
+	select {
+	case err := <-changes:
+		t.Fatalf("Unexpected change: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	rewrite(fmt.Sprintf(`
+level: debug
+encoding: console
+encoderConfig: {messageKey: M, levelKey: "", timeKey: "", nameKey: ""}
+outputPaths: ["tracked://%v"]
+sampling: null
+initialFields:
+  gen: 2
+`, second))
+	clock.Add(time.Second)
+	require.NoError(t, <-changes, "Unexpected error reloading config.")
+
+	logger.Debug("debug")
+	for i := 0; i < 3; i++ {
+		logger.Info("sampled")
+	}
+	named.Info("with fields")
+	require.NoError(t, logger.Close(), "Unexpected error closing logger.")
+
+	assert.Equal(t, []string{
+		`{"msg":"sampled","gen":1}`,
+		`{"logger":"named","msg":"with fields","gen":1,"k":"v"}`,
+	}, readLines(t, first), "Unexpected output before reloading.")
+	assert.Equal(t, []string{
+		"debug\t{\"gen\": 2}",
+		"sampled\t{\"gen\": 2}",
+		"sampled\t{\"gen\": 2}",
+		"sampled\t{\"gen\": 2}",
+		"with fields\t{\"gen\": 2, \"k\": \"v\"}",
+	}, readLines(t, second), "Unexpected output after reloading.")
+
+	require.Len(t, tracked(first), 1, "Expected the first output to be opened once.")
+	assert.True(t, tracked(first)[0].closed, "Expected the removed output to be closed.")
+	require.Len(t, tracked(second), 1, "Expected the second output to be opened once.")
+	assert.True(t, tracked(second)[0].closed, "Expected Close to close the current output.")
+}
+
+func TestWatchConfigJSON(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out.log")
+	path := filepath.Join(t.TempDir(), "logging.json")
+	require.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf(
+		`{"level": "warn", "disableCaller": true, "encoderConfig": {"messageKey": "msg", "levelKey": "", "timeKey": ""}, "outputPaths": [%q]}`, out,
+	)), 0o666), "Failed to write config file.")
+
+	logger, err := WatchConfig(path, nil)
+	require.NoError(t, err, "Unexpected error watching config.")
+	logger.Info("info")
+	logger.Warn("warn")
+	require.NoError(t, logger.Close(), "Unexpected error closing logger.")
+
+	assert.Equal(t, []string{`{"msg":"warn"}`}, readLines(t, out), "Expected JSON files to be decoded by default.")
+
+	path, _ = configFile(t, "level: warn")
+	_, err = WatchConfig(path, nil)
+	assert.ErrorContains(t, err, "parse "+path, "Expected YAML to need WatchDecoder.")
+}
+
+func TestWatchConfigErrors(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out.log")
+	valid := fmt.Sprintf(`
+disableCaller: true
+encoderConfig: {messageKey: msg, levelKey: "", timeKey: ""}
+outputPaths: [%q]
+`, out)
+	path, rewrite := configFile(t, valid)
+
+	clock := ztest.NewMockClock()
+	onChange, changes := watchChanges()
+	logger, err := watchYAML(path, onChange, WatchClock(clock))
+	require.NoError(t, err, "Unexpected error watching config.")
+	defer func() {
+		assert.NoError(t, logger.Close(), "Unexpected error closing logger.")
+	}()
+
+	tests := []struct {
+		desc     string
+		contents string
+		wantErr  string
+	}{
+		{"invalid YAML", "level: [", "parse " + path},
+		{"unknown level", "level: verbose", `unrecognized level: "verbose"`},
+		{"unknown encoding", "encoding: xml", `build ` + path + `: no encoder registered for name "xml"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			rewrite(tt.contents)
+			clock.Add(time.Second)
+			assert.ErrorContains(t, <-changes, tt.wantErr, "Unexpected error.")
+
+			// Bad files are reported once.
+			clock.Add(time.Second)
+			select {
+			case err := <-changes:
+				t.Fatalf("Unexpected change: %v", err)
+			case <-time.After(10 * time.Millisecond):
+			}
+
+			logger.Info(tt.desc)
+		})
+	}
+
+	require.NoError(t, os.Remove(path), "Failed to remove config file.")
+	clock.Add(time.Second)
+	assert.ErrorIs(t, <-changes, os.ErrNotExist, "Expected an error for a missing file.")
+
+	rewrite(valid)
+	clock.Add(time.Second)
+	assert.NoError(t, <-changes, "Unexpected error reloading config.")
+	logger.Info("reloaded")
+
+	assert.Equal(t, []string{
+		`{"msg":"invalid YAML"}`,
+		`{"msg":"unknown level"}`,
+		`{"msg":"unknown encoding"}`,
+		`{"msg":"reloaded"}`,
+	}, readLines(t, out), "Expected the Logger to keep its configuration after errors.")
+}
+
+func TestWatchConfigInitialErrors(t *testing.T) {
+	_, err := watchYAML(filepath.Join(t.TempDir(), "missing.yaml"), nil)
+	assert.ErrorIs(t, err, os.ErrNotExist, "Expected an error for a missing file.")
+
+	path, _ := configFile(t, "encoding: xml")
+	_, err = watchYAML(path, nil)
+	assert.ErrorContains(t, err, `no encoder registered for name "xml"`, "Expected an error for an invalid config.")
+}
+
+func TestWatchConfigConcurrentReloads(t *testing.T) {
+	dir := t.TempDir()
+	outputs := []string{filepath.Join(dir, "a.log"), filepath.Join(dir, "b.log")}
+	contents := func(i int) string {
+		return fmt.Sprintf("outputPaths: [%q]\nsampling: null\n# %d\n", outputs[i%2], i)
+	}
+	path, rewrite := configFile(t, contents(0))
+
+	clock := ztest.NewMockClock()
+	onChange, changes := watchChanges()
+	logger, err := watchYAML(path, onChange, WatchClock(clock))
+	require.NoError(t, err, "Unexpected error watching config.")
+
+	// Log until the reloads are done.
+	const goroutines = 4
+	var (
+		wg      sync.WaitGroup
+		done    = make(chan struct{})
+		written = make([]int, goroutines)
+	)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			log := logger.With(Int("goroutine", i))
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				log.Info("entry")
+				written[i]++
+			}
+		}(i)
+	}
+	for i := 1; i <= 10; i++ {
+		rewrite(contents(i))
+		clock.Add(time.Second)
+		require.NoError(t, <-changes, "Unexpected error reloading config.")
+	}
+	close(done)
+	wg.Wait()
+	require.NoError(t, logger.Close(), "Unexpected error closing logger.")
+
+	var want int
+	for _, n := range written {
+		want += n
+	}
+	total := len(readLines(t, outputs[0])) + len(readLines(t, outputs[1]))
+	assert.Equal(t, want, total, "Expected no entries to be lost while reloading.")
+}
+
+func TestWatchConfigReusesSinks(t *testing.T) {
+	tracked := stubTrackedSinks(t)
+	dir := t.TempDir()
+	kept := filepath.Join(dir, "kept.log")
+	removed := filepath.Join(dir, "removed.log")
+	added := filepath.Join(dir, "added.log")
+	contents := func(paths ...string) string {
+		urls := make([]string, len(paths))
+		for i, p := range paths {
+			urls[i] = fmt.Sprintf("%q", "tracked://"+p)
+		}
+		return fmt.Sprintf(`
+disableCaller: true
+encoderConfig: {messageKey: msg, levelKey: "", timeKey: ""}
+outputPaths: [%v]
+errorOutputPaths: [%v]
+`, strings.Join(urls, ", "), urls[0])
+	}
+	path, rewrite := configFile(t, contents(kept, removed))
+
+	clock := ztest.NewMockClock()
+	onChange, changes := watchChanges()
+	logger, err := watchYAML(path, onChange, WatchClock(clock))
+	require.NoError(t, err, "Unexpected error watching config.")
+	logger.Info("before")
+
+	rewrite(contents(kept, added))
+	clock.Add(time.Second)
+	require.NoError(t, <-changes, "Unexpected error reloading config.")
+	logger.Info("after")
+
+	require.Len(t, tracked(kept), 1, "Expected the kept output to stay open across reloads.")
+	assert.False(t, tracked(kept)[0].closed, "Expected the kept output to stay open across reloads.")
+	require.Len(t, tracked(removed), 1, "Expected the removed output to be opened once.")
+	assert.True(t, tracked(removed)[0].closed, "Expected the removed output to be closed.")
+
+	require.NoError(t, logger.Close(), "Unexpected error closing logger.")
+	assert.True(t, tracked(kept)[0].closed, "Expected Close to close the kept output.")
+	assert.True(t, tracked(added)[0].closed, "Expected Close to close the added output.")
+	assert.Equal(t, []string{`{"msg":"before"}`, `{"msg":"after"}`}, readLines(t, kept),
+		"Unexpected output in the kept file.")
+}
+
+// failingCloseSink is a Sink whose Close fails.
+type failingCloseSink struct {
+	zapcore.WriteSyncer
+}
+
+func (failingCloseSink) Close() error { return errors.New("close failed") }
+
+func TestWatchConfigCloseErrors(t *testing.T) {
+	tracked := stubTrackedSinks(t)
+	require.NoError(t, _sinkRegistry.RegisterSink("failclose", func(*url.URL) (Sink, error) {
+		return failingCloseSink{zapcore.AddSync(io.Discard)}, nil
+	}), "Failed to register sink.")
+	out := filepath.Join(t.TempDir(), "out.log")
+	path, rewrite := configFile(t, "outputPaths: [\"failclose://\"]\n")
+
+	type change struct {
+		cfg Config
+		err error
+	}
+	changes := make(chan change, 1)
+	clock := ztest.NewMockClock()
+	logger, err := watchYAML(path, func(cfg Config, err error) {
+		changes <- change{cfg, err}
+	}, WatchClock(clock))
+	require.NoError(t, err, "Unexpected error watching config.")
+
+	rewrite(fmt.Sprintf("outputPaths: [%q]\n", "tracked://"+out))
+	clock.Add(time.Second)
+	got := <-changes
+	assert.ErrorContains(t, got.err, "close previous outputs: close failed", "Expected the close error.")
+	assert.Equal(t, []string{"tracked://" + out}, got.cfg.OutputPaths, "Expected the new Config with the close error.")
+
+	require.NoError(t, logger.Close(), "Unexpected error closing logger.")
+	assert.Len(t, tracked(out), 1, "Expected the new output to be in use.")
+}
@@ -38,13 +38,16 @@ var (
 		"json": func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
 			return zapcore.NewJSONEncoder(encoderConfig), nil
 		},
+		"logfmt": func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+			return zapcore.NewLogfmtEncoder(encoderConfig), nil
+		},
 	}
 	_encoderMutex sync.RWMutex
 )
 
 // RegisterEncoder registers an encoder constructor, which the Config struct
-// can then reference. By default, the "json" and "console" encoders are
-// registered.
+// can then reference. By default, the "json", "console", and "logfmt"
+// encoders are registered.
 //
 // Attempting to register an encoder whose name is already taken returns an
 // error.
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+// ConfigFromEnv returns a copy of base with the settings in environment
+// variables applied. The variables are those of the flags declared by
+// Config.RegisterFlags, upper-cased, with underscores instead of hyphens,
+// and with the given prefix. For example, with the prefix "LOG_":
+//
+//	LOG_LEVEL=debug
+//	LOG_ENCODING=console
+//	LOG_OUTPUT_PATHS=stdout,/var/log/app.log
+//	LOG_ERROR_OUTPUT_PATHS=stderr
+//	LOG_DEVELOPMENT=true
+//	LOG_SAMPLING=100:100 (INITIAL:THEREAFTER[:TICK], or "off")
+//	LOG_DISABLE_CALLER=true
+//	LOG_DISABLE_STACKTRACE=true
+//	LOG_INITIAL_FIELDS=service=api,region=us-east
+//
+// Unset and empty variables are ignored. If any variable can't be parsed,
+// ConfigFromEnv returns an error naming each of them.
+func ConfigFromEnv(prefix string, base Config) (Config, error) {
+	cfg := base
+	fs := flag.NewFlagSet("env", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	cfg.RegisterFlags(fs, "")
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		name := prefix + strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		val := os.Getenv(name)
+		if val == "" {
+			return
+		}
+		if setErr := f.Value.Set(val); setErr != nil {
+			err = multierr.Append(err, fmt.Errorf("invalid %s=%q: %w", name, val, setErr))
+		}
+	})
+	return cfg, err
+}
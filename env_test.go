@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("LOG_ENCODING", "console")
+	t.Setenv("LOG_OUTPUT_PATHS", "stdout,stderr")
+	t.Setenv("LOG_SAMPLING", "5:10:1m")
+	t.Setenv("LOG_DISABLE_CALLER", "1")
+	t.Setenv("LOG_INITIAL_FIELDS", "service=api")
+	t.Setenv("LOG_ERROR_OUTPUT_PATHS", "")
+	t.Setenv("OTHER_LEVEL", "debug")
+
+	base := NewProductionConfig()
+	cfg, err := ConfigFromEnv("LOG_", base)
+	require.NoError(t, err, "Unexpected error reading the environment.")
+
+	assert.Equal(t, WarnLevel, cfg.Level.Level(), "Unexpected level.")
+	assert.Equal(t, "console", cfg.Encoding, "Unexpected encoding.")
+	assert.Equal(t, []string{"stdout", "stderr"}, cfg.OutputPaths, "Unexpected output paths.")
+	assert.Equal(t, []string{"stderr"}, cfg.ErrorOutputPaths, "Expected empty variables to be ignored.")
+	assert.Equal(t, &SamplingConfig{Initial: 5, Thereafter: 10, Tick: time.Minute}, cfg.Sampling, "Unexpected sampling.")
+	assert.True(t, cfg.DisableCaller, "Expected caller to be disabled.")
+	assert.False(t, cfg.DisableStacktrace, "Expected unset variables to be ignored.")
+	assert.Equal(t, map[string]interface{}{"service": "api"}, cfg.InitialFields, "Unexpected initial fields.")
+	assert.Equal(t, InfoLevel, base.Level.Level(), "Expected the base Config to be unchanged.")
+
+	_, err = cfg.Build()
+	assert.NoError(t, err, "Unexpected error building a logger.")
+}
+
+func TestConfigFromEnvErrors(t *testing.T) {
+	t.Setenv("APP_LOG_LEVEL", "verbose")
+	t.Setenv("APP_LOG_DEVELOPMENT", "yes")
+
+	_, err := ConfigFromEnv("APP_LOG_", NewProductionConfig())
+	require.Error(t, err, "Expected an error for invalid variables.")
+	assert.Equal(t,
+		`invalid APP_LOG_DEVELOPMENT="yes": expected a boolean, like true or false, got "yes"; `+
+			`invalid APP_LOG_LEVEL="verbose": unrecognized level: "verbose"`,
+		err.Error(), "Expected every invalid variable to be reported.")
+}
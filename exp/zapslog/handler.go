@@ -40,6 +40,15 @@ type Handler struct {
 	addStackAt slog.Level
 	callerSkip int
 
+	ctxExtractors []func(context.Context) []zapcore.Field
+
+	// The Core before WithAttrs applied any groups, and the fields added
+	// to it since, so that fields from ctxExtractors can be added outside
+	// the groups. Only set if there are ctxExtractors and groups have been
+	// applied.
+	ungrouped     zapcore.Core
+	groupedFields []zapcore.Field
+
 	// List of unapplied groups.
 	//
 	// These are applied only if we encounter a real field
@@ -142,7 +151,13 @@ func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
 		Message:    record.Message,
 		LoggerName: h.name,
 	}
-	ce := h.core.Check(ent, nil)
+	core := h.core
+	if h.ungrouped != nil {
+		// Fields from ctxExtractors go before the groups, so the fields
+		// added in groups are written after them instead of by With.
+		core = h.ungrouped
+	}
+	ce := core.Check(ent, nil)
 	if ce == nil {
 		return nil
 	}
@@ -165,10 +180,16 @@ func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
 		// zapslog/handler log/slog.(*Logger).log
 		// slog/logger log/slog.(*Logger).log
 		// slog/logger log/slog.(*Logger).<level>
-		ce.Stack = stacktrace.Take(3 + h.callerSkip)
+		ce.Stack, ce.StackFrames = stacktrace.TakeWithFrames(3 + h.callerSkip)
 	}
 
-	fields := make([]zapcore.Field, 0, record.NumAttrs()+len(h.groups))
+	fields := make([]zapcore.Field, 0, record.NumAttrs()+len(h.groups)+len(h.groupedFields))
+	if ctx != nil {
+		for _, extract := range h.ctxExtractors {
+			fields = append(fields, extract(ctx)...)
+		}
+	}
+	fields = append(fields, h.groupedFields...)
 
 	var addedNamespace bool
 	record.Attrs(func(attr slog.Attr) bool {
@@ -212,6 +233,13 @@ func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
 
 	cloned := *h
 	cloned.core = h.core.With(fields)
+	if len(h.ctxExtractors) > 0 && (addedNamespace || h.ungrouped != nil) {
+		if h.ungrouped == nil {
+			cloned.ungrouped = h.core
+		}
+		n := len(h.groupedFields)
+		cloned.groupedFields = append(h.groupedFields[:n:n], fields...)
+	}
 	if addedNamespace {
 		// These groups have been applied so we can clear them.
 		cloned.groups = nil
@@ -24,6 +24,7 @@ package zapslog
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"log/slog"
 	"sync"
@@ -33,6 +34,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest"
 	"go.uber.org/zap/zaptest/observer"
@@ -155,6 +157,86 @@ func TestWithName(t *testing.T) {
 	})
 }
 
+func TestWithContextExtractor(t *testing.T) {
+	type ctxKey struct{}
+	requestID := func(ctx context.Context) []zapcore.Field {
+		if id, ok := ctx.Value(ctxKey{}).(string); ok {
+			return []zapcore.Field{zap.String("request_id", id)}
+		}
+		return nil
+	}
+
+	fac, observedLogs := observer.New(zapcore.DebugLevel)
+	sl := slog.New(NewHandler(fac, WithContextExtractor(requestID))).WithGroup("g")
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "42")
+	sl.InfoContext(ctx, "with context", "k", "v")
+	sl.Info("without context")
+
+	logs := observedLogs.TakeAll()
+	require.Len(t, logs, 2, "Expected exactly two entries to be logged")
+	assert.Equal(t, map[string]any{
+		"request_id": "42",
+		"g":          map[string]any{"k": "v"},
+	}, logs[0].ContextMap(), "Expected context fields outside of groups.")
+	assert.Empty(t, logs[1].ContextMap(), "Unexpected fields without context.")
+}
+
+func TestWithContextExtractorInGroups(t *testing.T) {
+	requestID := func(context.Context) []zapcore.Field {
+		return []zapcore.Field{zap.String("request_id", "r1")}
+	}
+
+	fac, observedLogs := observer.New(zapcore.DebugLevel)
+	sl := slog.New(NewHandler(fac, WithContextExtractor(requestID))).
+		With("top", 0).
+		WithGroup("g").With("a", 1).
+		WithGroup("h").With("b", 2)
+
+	sl.InfoContext(context.Background(), "hello", "c", 3)
+	sl.WithGroup("empty").InfoContext(context.Background(), "no attrs")
+
+	logs := observedLogs.TakeAll()
+	require.Len(t, logs, 2, "Expected exactly two entries to be logged")
+	want := map[string]any{
+		"top":        int64(0),
+		"request_id": "r1",
+		"g": map[string]any{
+			"a": int64(1),
+			"h": map[string]any{"b": int64(2), "c": int64(3)},
+		},
+	}
+	assert.Equal(t, want, logs[0].ContextMap(), "Expected context fields outside of groups.")
+	delete(want["g"].(map[string]any)["h"].(map[string]any), "c")
+	assert.Equal(t, want, logs[1].ContextMap(), "Expected context fields outside of groups.")
+}
+
+func TestTraceFields(t *testing.T) {
+	type spanKey struct{}
+	spanContext := func(ctx context.Context) (zap.TraceContext, bool) {
+		tc, ok := ctx.Value(spanKey{}).(zap.TraceContext)
+		return tc, ok
+	}
+
+	fac, observedLogs := observer.New(zapcore.DebugLevel)
+	sl := slog.New(NewHandler(fac, WithContextExtractor(zap.TraceFields(spanContext, zap.TraceKeys{}))))
+
+	ctx := context.WithValue(context.Background(), spanKey{}, zap.TraceContext{
+		TraceID:    [16]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     [8]byte{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: 1,
+	})
+	sl.InfoContext(ctx, "traced")
+
+	logs := observedLogs.TakeAll()
+	require.Len(t, logs, 1, "Expected exactly one entry to be logged")
+	assert.Equal(t, map[string]any{
+		"trace_id":    "4bf92f3577b34da6a3ce929d0e0e4736",
+		"span_id":     "00f067aa0ba902b7",
+		"trace_flags": "01",
+	}, logs[0].ContextMap(), "Unexpected trace fields.")
+}
+
 func TestInlineGroup(t *testing.T) {
 	fac, observedLogs := observer.New(zapcore.DebugLevel)
 
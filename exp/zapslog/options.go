@@ -22,7 +22,12 @@
 
 package zapslog
 
-import "log/slog"
+import (
+	"context"
+	"log/slog"
+
+	"go.uber.org/zap/zapcore"
+)
 
 // A HandlerOption configures a slog Handler.
 type HandlerOption interface {
@@ -70,3 +75,19 @@ func AddStacktraceAt(lvl slog.Level) HandlerOption {
 		log.addStackAt = lvl
 	})
 }
+
+// WithContextExtractor configures the Handler to add fields taken from the
+// context passed to Handle, such as request IDs stored by middleware. The
+// fields are added at the top level, outside any groups, before the record's
+// attributes. Attributes added with With inside a group are then passed
+// to the Core with every record rather than once. Repeated use of
+// WithContextExtractor is additive.
+//
+// Use the same extractors as passed to zap.WithContextExtractor, such as
+// zap.TraceFields, to get the same fields from slog and zap loggers.
+func WithContextExtractor(fns ...func(context.Context) []zapcore.Field) HandlerOption {
+	return handlerOptionFunc(func(h *Handler) {
+		n := len(h.ctxExtractors)
+		h.ctxExtractors = append(h.ctxExtractors[:n:n], fns...)
+	})
+}
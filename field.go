@@ -378,6 +378,20 @@ func StackSkip(key string, skip int) Field {
 	return String(key, stacktrace.Take(skip+1)) // skip StackSkip
 }
 
+// StackFrames constructs a field that stores a stacktrace of the current
+// goroutine under the provided key, like Stack, but as an array of frames.
+// Each frame is an object with "function", "file", and "line" keys, matching
+// the output of zapcore.FramesStacktraceEncoder.
+func StackFrames(key string) Field {
+	return StackFramesSkip(key, 1) // skip StackFrames
+}
+
+// StackFramesSkip constructs a field similarly to StackFrames, but also skips
+// the given number of frames from the top of the stacktrace.
+func StackFramesSkip(key string, skip int) Field {
+	return Array(key, zapcore.StackFrames(stacktrace.TakeFrames(skip+1))) // skip StackFramesSkip
+}
+
 // Duration constructs a field with the given key and value. The encoder
 // controls how the duration is serialized.
 func Duration(key string, val time.Duration) Field {
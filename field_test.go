@@ -21,14 +21,17 @@
 package zap
 
 import (
+	"fmt"
 	"math"
 	"net"
 	"regexp"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/internal/stacktrace"
 	"go.uber.org/zap/zapcore"
 )
@@ -292,6 +295,37 @@ func TestStackSkipFieldWithSkip(t *testing.T) {
 	assertCanBeReused(t, f)
 }
 
+func TestStackFramesField(t *testing.T) {
+	// Take both on the same line, so they agree on it.
+	text, frames := Stack("stacktrace"), StackFrames("stacktrace")
+	assert.Equal(t, "stacktrace", frames.Key, "Unexpected field key.")
+	assert.Equal(t, zapcore.ArrayMarshalerType, frames.Type, "Unexpected field type.")
+
+	enc := zapcore.NewMapObjectEncoder()
+	frames.AddTo(enc)
+	got := enc.Fields["stacktrace"].([]interface{})
+	require.NotEmpty(t, got, "Expected at least one frame.")
+	top := got[0].(map[string]interface{})
+	assert.Equal(t, "go.uber.org/zap.TestStackFramesField", top["function"], "Expected the stack to start with the test.")
+	assert.True(t, strings.HasSuffix(top["file"].(string), "field_test.go"), "Unexpected file %v.", top["file"])
+
+	lines := strings.Split(text.String, "\n")
+	assert.Len(t, got, len(lines)/2, "Expected the same frames as Stack.")
+	assert.Equal(t, lines[0], top["function"], "Expected the same top frame as Stack.")
+	assert.Equal(t, fmt.Sprintf("\t%v:%v", top["file"], top["line"]), lines[1], "Expected the same top frame as Stack.")
+	assertCanBeReused(t, frames)
+}
+
+func TestStackFramesSkipField(t *testing.T) {
+	f := StackFramesSkip("stacktrace", 1)
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	got := enc.Fields["stacktrace"].([]interface{})
+	assert.Len(t, got, len(stacktrace.TakeFrames(1)), "Unexpected number of frames.")
+	assert.Equal(t, stacktrace.TakeFrames(1)[0].Function, got[0].(map[string]interface{})["function"],
+		"Expected the stack to start above the test.")
+}
+
 func TestDict(t *testing.T) {
 	tests := []struct {
 		desc     string
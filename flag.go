@@ -21,7 +21,13 @@
 package zap
 
 import (
+	"errors"
 	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"go.uber.org/zap/zapcore"
 )
@@ -37,3 +43,184 @@ func LevelFlag(name string, defaultLevel zapcore.Level, usage string) *zapcore.L
 	flag.Var(&lvl, name, usage)
 	return &lvl
 }
+
+// RegisterFlags declares flags on fs that override the Config's level,
+// encoding, output paths, sampling, caller and stacktrace toggles, and
+// initial fields. Each flag's name starts with prefix, and its default is
+// the Config's current setting. For example, with the prefix "log-":
+//
+//	-log-level=debug
+//	-log-encoding=console
+//	-log-output-paths=stdout,/var/log/app.log
+//	-log-error-output-paths=stderr
+//	-log-development
+//	-log-sampling=100:100 (INITIAL:THEREAFTER[:TICK], or "off")
+//	-log-disable-caller
+//	-log-disable-stacktrace
+//	-log-initial-fields=service=api,region=us-east
+//
+// Lists are comma-separated and replace the Config's lists. Initial fields
+// are added to the Config's, and their values are strings. The flags don't
+// modify values shared with other Configs, such as Level, so overriding the
+// level of a copy of a Config leaves the original alone.
+//
+// See ConfigFromEnv to read the same settings from environment variables.
+func (cfg *Config) RegisterFlags(fs *flag.FlagSet, prefix string) {
+	fs.Var(&levelFlag{cfg}, prefix+"level", "minimum enabled logging level")
+	fs.StringVar(&cfg.Encoding, prefix+"encoding", cfg.Encoding, "log encoding, such as json or console")
+	fs.Var(&listFlag{&cfg.OutputPaths}, prefix+"output-paths", "comma-separated URLs or file paths to write logs to")
+	fs.Var(&listFlag{&cfg.ErrorOutputPaths}, prefix+"error-output-paths", "comma-separated URLs or file paths to write internal logger errors to")
+	fs.Var(&boolFlag{&cfg.Development}, prefix+"development", "enable development mode")
+	fs.Var(&samplingFlag{&cfg.Sampling}, prefix+"sampling", `sampling policy as INITIAL:THEREAFTER[:TICK], or "off"`)
+	fs.Var(&boolFlag{&cfg.DisableCaller}, prefix+"disable-caller", "don't annotate logs with the calling function")
+	fs.Var(&boolFlag{&cfg.DisableStacktrace}, prefix+"disable-stacktrace", "don't capture stack traces")
+	fs.Var(&fieldsFlag{&cfg.InitialFields}, prefix+"initial-fields", "comma-separated KEY=VALUE fields to add to every log")
+}
+
+// levelFlag sets a Config's Level to a new AtomicLevel, leaving any shared
+// AtomicLevel alone.
+type levelFlag struct{ cfg *Config }
+
+func (f *levelFlag) Set(s string) error {
+	var lvl zapcore.Level
+	if err := lvl.UnmarshalText([]byte(s)); err != nil {
+		return err
+	}
+	f.cfg.Level = NewAtomicLevelAt(lvl)
+	return nil
+}
+
+func (f *levelFlag) String() string {
+	if f == nil || f.cfg == nil || f.cfg.Level == (AtomicLevel{}) {
+		return ""
+	}
+	return f.cfg.Level.String()
+}
+
+type listFlag struct{ list *[]string }
+
+func (f *listFlag) Set(s string) error {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	*f.list = list
+	return nil
+}
+
+func (f *listFlag) String() string {
+	if f == nil || f.list == nil {
+		return ""
+	}
+	return strings.Join(*f.list, ",")
+}
+
+type boolFlag struct{ b *bool }
+
+func (f *boolFlag) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return fmt.Errorf("expected a boolean, like true or false, got %q", s)
+	}
+	*f.b = b
+	return nil
+}
+
+func (f *boolFlag) String() string {
+	if f == nil || f.b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*f.b)
+}
+
+func (f *boolFlag) IsBoolFlag() bool { return true }
+
+// samplingFlag sets the sampling policy to a new SamplingConfig, keeping the
+// rest of the previous one.
+type samplingFlag struct{ sampling **SamplingConfig }
+
+func (f *samplingFlag) Set(s string) error {
+	if s == "off" {
+		*f.sampling = nil
+		return nil
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return errors.New(`expected INITIAL:THEREAFTER[:TICK], like 100:100:1s, or "off"`)
+	}
+	initial, err := strconv.Atoi(parts[0])
+	if err != nil || initial < 0 {
+		return fmt.Errorf("invalid initial count %q: expected a non-negative integer", parts[0])
+	}
+	thereafter, err := strconv.Atoi(parts[1])
+	if err != nil || thereafter < 0 {
+		return fmt.Errorf("invalid thereafter count %q: expected a non-negative integer", parts[1])
+	}
+
+	var scfg SamplingConfig
+	if *f.sampling != nil {
+		scfg = **f.sampling
+	}
+	scfg.Initial, scfg.Thereafter = initial, thereafter
+	if len(parts) == 3 {
+		tick, err := time.ParseDuration(parts[2])
+		if err != nil || tick <= 0 {
+			return fmt.Errorf("invalid tick %q: expected a positive duration, like 1s", parts[2])
+		}
+		scfg.Tick = tick
+	}
+	*f.sampling = &scfg
+	return nil
+}
+
+func (f *samplingFlag) String() string {
+	if f == nil || f.sampling == nil {
+		return ""
+	}
+	scfg := *f.sampling
+	if scfg == nil {
+		return "off"
+	}
+	s := fmt.Sprintf("%d:%d", scfg.Initial, scfg.Thereafter)
+	if scfg.Tick > 0 {
+		s += ":" + scfg.Tick.String()
+	}
+	return s
+}
+
+// fieldsFlag adds to the initial fields in a new map.
+type fieldsFlag struct{ fields *map[string]interface{} }
+
+func (f *fieldsFlag) Set(s string) error {
+	fields := make(map[string]interface{}, len(*f.fields))
+	for k, v := range *f.fields {
+		fields[k] = v
+	}
+	for _, pair := range strings.Split(s, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || k == "" {
+			return fmt.Errorf("invalid field %q: expected KEY=VALUE", pair)
+		}
+		fields[k] = v
+	}
+	*f.fields = fields
+	return nil
+}
+
+func (f *fieldsFlag) String() string {
+	if f == nil || f.fields == nil {
+		return ""
+	}
+	pairs := make([]string, 0, len(*f.fields))
+	for k, v := range *f.fields {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", k, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -23,11 +23,14 @@ package zap
 import (
 	"flag"
 	"io"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"go.uber.org/zap/zapcore"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type flagTestCase struct {
@@ -101,3 +104,95 @@ func TestLevelFlagsAreIndependent(t *testing.T) {
 	assert.Equal(t, InfoLevel, *consoleLevel, "Expected file logging level to remain unchanged.")
 	assert.Equal(t, DebugLevel, *fileLevel, "Expected console logging level to have changed.")
 }
+
+func TestConfigRegisterFlags(t *testing.T) {
+	base := NewProductionConfig()
+	base.InitialFields = map[string]interface{}{"service": "api"}
+	cfg := base
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	cfg.RegisterFlags(fs, "log-")
+
+	assert.Equal(t, "info", fs.Lookup("log-level").DefValue, "Expected the Config's level as the default.")
+	assert.Equal(t, "100:100", fs.Lookup("log-sampling").DefValue, "Expected the Config's sampling as the default.")
+	assert.Equal(t, "service=api", fs.Lookup("log-initial-fields").DefValue, "Expected the Config's fields as the default.")
+
+	err := fs.Parse([]string{
+		"-log-level=debug",
+		"-log-encoding", "console",
+		"-log-output-paths=stdout, /tmp/app.log",
+		"-log-error-output-paths=stdout",
+		"-log-development",
+		"-log-sampling=10:50:5s",
+		"-log-disable-caller",
+		"-log-disable-stacktrace=true",
+		"-log-initial-fields=region=us-east,zone=a",
+		"-log-initial-fields=zone=b",
+	})
+	require.NoError(t, err, "Unexpected error parsing flags.")
+
+	assert.Equal(t, DebugLevel, cfg.Level.Level(), "Unexpected level.")
+	assert.Equal(t, "console", cfg.Encoding, "Unexpected encoding.")
+	assert.Equal(t, []string{"stdout", "/tmp/app.log"}, cfg.OutputPaths, "Unexpected output paths.")
+	assert.Equal(t, []string{"stdout"}, cfg.ErrorOutputPaths, "Unexpected error output paths.")
+	assert.True(t, cfg.Development, "Expected development mode.")
+	assert.Equal(t, &SamplingConfig{Initial: 10, Thereafter: 50, Tick: 5 * time.Second}, cfg.Sampling, "Unexpected sampling.")
+	assert.True(t, cfg.DisableCaller, "Expected caller to be disabled.")
+	assert.True(t, cfg.DisableStacktrace, "Expected stacktraces to be disabled.")
+	assert.Equal(t, map[string]interface{}{"service": "api", "region": "us-east", "zone": "b"}, cfg.InitialFields,
+		"Unexpected initial fields.")
+
+	assert.Equal(t, InfoLevel, base.Level.Level(), "Expected the original Config's level to be unchanged.")
+	assert.Equal(t, 100, base.Sampling.Initial, "Expected the original Config's sampling to be unchanged.")
+	assert.Equal(t, map[string]interface{}{"service": "api"}, base.InitialFields, "Expected the original Config's fields to be unchanged.")
+
+	require.NoError(t, fs.Parse([]string{"-log-sampling=off"}), "Unexpected error parsing flags.")
+	assert.Nil(t, cfg.Sampling, "Expected sampling to be disabled.")
+}
+
+func TestConfigRegisterFlagsAfterBuild(t *testing.T) {
+	cfg := NewProductionConfig()
+	cfg.OutputPaths = []string{filepath.Join(t.TempDir(), "out.log")}
+
+	first, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+	defer first.Close()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	cfg.RegisterFlags(fs, "log-")
+	require.NoError(t, fs.Parse([]string{"-log-level=error"}), "Unexpected error parsing flags.")
+
+	second, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+	defer second.Close()
+
+	assert.Equal(t, ErrorLevel, zapcore.LevelOf(second.Core()), "Expected the flag's level after a rebuild.")
+	assert.Equal(t, InfoLevel, zapcore.LevelOf(first.Core()), "Expected the first logger's level to be unchanged.")
+}
+
+func TestConfigRegisterFlagsErrors(t *testing.T) {
+	tests := []struct {
+		arg     string
+		wantErr string
+	}{
+		{"-level=verbose", `unrecognized level: "verbose"`},
+		{"-development=yes", `expected a boolean, like true or false, got "yes"`},
+		{"-sampling=100", `expected INITIAL:THEREAFTER[:TICK], like 100:100:1s, or "off"`},
+		{"-sampling=a:1", `invalid initial count "a"`},
+		{"-sampling=1:-1", `invalid thereafter count "-1"`},
+		{"-sampling=1:1:soon", `invalid tick "soon"`},
+		{"-initial-fields=service", `invalid field "service": expected KEY=VALUE`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			cfg := NewProductionConfig()
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			cfg.RegisterFlags(fs, "")
+			assert.ErrorContains(t, fs.Parse([]string{tt.arg}), tt.wantErr, "Unexpected error.")
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"go.uber.org/zap/zapcore"
 )
@@ -138,3 +139,167 @@ func decodePutJSON(body io.Reader) (zapcore.Level, error) {
 	}
 	return *pld.Level, nil
 }
+
+// ServeHTTP is a JSON endpoint that can report on or change the levels of
+// named loggers. It works like AtomicLevel.ServeHTTP, with the following
+// additions.
+//
+// # GET
+//
+// The GET request returns the level that applies to loggers without a more
+// specific one, the levels set by name, and the times at which temporary
+// levels revert:
+//
+//	{"level":"info","levels":{"payments":"debug"},"expires":{"payments":"2006-01-02T15:04:05Z"}}
+//
+// With a logger query parameter, it returns the level that applies to that
+// logger instead:
+//
+//	curl localhost:8080/log/level?logger=payments.stripe
+//	{"logger":"payments.stripe","level":"debug"}
+//
+// # PUT
+//
+// The PUT request sets the level for the logger named by the logger field,
+// and its descendants. If the logger is omitted, it sets the level for all
+// loggers without a more specific one. If a duration is given in the for
+// field, the level reverts once it has passed.
+//
+//	curl -X PUT localhost:8080/log/level -d level=debug -d logger=payments -d for=10m
+//	curl -X PUT localhost:8080/log/level -H "Content-Type: application/json" \
+//		-d '{"level":"debug","logger":"payments","for":"10m"}'
+//
+// # DELETE
+//
+// The DELETE request removes the level set for the logger named by the
+// logger query parameter, so that it uses the level of its closest
+// configured ancestor.
+//
+//	curl -X DELETE localhost:8080/log/level?logger=payments
+func (nl NamedLevels) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := nl.serveHTTP(w, r); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = fmt.Fprintf(w, "internal error: %v", err)
+	}
+}
+
+func (nl NamedLevels) serveHTTP(w http.ResponseWriter, r *http.Request) error {
+	if nl.s == nil {
+		return errors.New("NamedLevels must be created with NewNamedLevels")
+	}
+
+	type errorResponse struct {
+		Error string `json:"error"`
+	}
+	type loggerPayload struct {
+		Logger  string        `json:"logger"`
+		Level   zapcore.Level `json:"level"`
+		Expires *time.Time    `json:"expires,omitempty"`
+	}
+	type levelsPayload struct {
+		Level   zapcore.Level            `json:"level"`
+		Levels  map[string]zapcore.Level `json:"levels"`
+		Expires map[string]time.Time     `json:"expires"`
+	}
+
+	enc := json.NewEncoder(w)
+	loggerResponse := func(name string) loggerPayload {
+		pld := loggerPayload{Logger: name, Level: nl.LevelFor(name)}
+		if at, ok := nl.Expirations()[name]; ok {
+			pld.Expires = &at
+		}
+		return pld
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if name, ok := r.URL.Query()["logger"]; ok {
+			return enc.Encode(loggerResponse(name[0]))
+		}
+		return enc.Encode(levelsPayload{
+			Level:   nl.LevelFor(""),
+			Levels:  nl.Levels(),
+			Expires: nl.Expirations(),
+		})
+
+	case http.MethodPut:
+		req, err := decodeNamedPutRequest(r.Header.Get("Content-Type"), r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(errorResponse{Error: err.Error()})
+		}
+		if req.ttl > 0 {
+			nl.SetTemporaryLevel(req.logger, req.level, req.ttl)
+		} else {
+			nl.SetLevel(req.logger, req.level)
+		}
+		return enc.Encode(loggerResponse(req.logger))
+
+	case http.MethodDelete:
+		name, ok := r.URL.Query()["logger"]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(errorResponse{Error: "must specify logger"})
+		}
+		nl.UnsetLevel(name[0])
+		return enc.Encode(loggerResponse(name[0]))
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return enc.Encode(errorResponse{
+			Error: "Only GET, PUT, and DELETE are supported.",
+		})
+	}
+}
+
+// namedPutRequest is a decoded PUT request to NamedLevels.ServeHTTP.
+type namedPutRequest struct {
+	logger string
+	level  zapcore.Level
+	ttl    time.Duration // zero if the level is permanent
+}
+
+// Decodes incoming PUT requests to NamedLevels.ServeHTTP.
+func decodeNamedPutRequest(contentType string, r *http.Request) (namedPutRequest, error) {
+	if contentType == "application/x-www-form-urlencoded" {
+		return decodeNamedPutURL(r)
+	}
+	return decodeNamedPutJSON(r.Body)
+}
+
+func decodeNamedPutURL(r *http.Request) (namedPutRequest, error) {
+	lvl, err := decodePutURL(r)
+	if err != nil {
+		return namedPutRequest{}, err
+	}
+	req := namedPutRequest{logger: r.FormValue("logger"), level: lvl}
+	if ttl := r.FormValue("for"); ttl != "" {
+		if req.ttl, err = parsePositiveDuration(ttl); err != nil {
+			return namedPutRequest{}, fmt.Errorf("invalid duration %q: %v", ttl, err)
+		}
+	}
+	return req, nil
+}
+
+func decodeNamedPutJSON(body io.Reader) (namedPutRequest, error) {
+	var pld struct {
+		Level  *zapcore.Level `json:"level"`
+		Logger string         `json:"logger"`
+		For    string         `json:"for"`
+	}
+	if err := json.NewDecoder(body).Decode(&pld); err != nil {
+		return namedPutRequest{}, fmt.Errorf("malformed request body: %v", err)
+	}
+	if pld.Level == nil {
+		return namedPutRequest{}, errors.New("must specify logging level")
+	}
+	req := namedPutRequest{logger: pld.Logger, level: *pld.Level}
+	if pld.For != "" {
+		ttl, err := parsePositiveDuration(pld.For)
+		if err != nil {
+			return namedPutRequest{}, fmt.Errorf("invalid duration %q: %v", pld.For, err)
+		}
+		req.ttl = ttl
+	}
+	return req, nil
+}
@@ -28,6 +28,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -238,6 +239,192 @@ func TestAtomicLevelServeHTTPBadLevel(t *testing.T) {
 	assert.NotContains(t, string(resBody), "<script>", "Unexpected error message.")
 }
 
+func TestNamedLevelsServeHTTP(t *testing.T) {
+	tests := []struct {
+		desc          string
+		method        string
+		query         string
+		contentType   string
+		body          string
+		expectedCode  int
+		expectedBody  string
+		expectedLevel map[string]zapcore.Level
+	}{
+		{
+			desc:         "GET",
+			method:       http.MethodGet,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"level":"info","levels":{"db":"warn"},"expires":{}}`,
+		},
+		{
+			desc:         "GET logger",
+			method:       http.MethodGet,
+			query:        "?logger=db.pool",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"logger":"db.pool","level":"warn"}`,
+		},
+		{
+			desc:          "PUT JSON",
+			method:        http.MethodPut,
+			body:          `{"level":"debug","logger":"payments"}`,
+			expectedCode:  http.StatusOK,
+			expectedBody:  `{"logger":"payments","level":"debug"}`,
+			expectedLevel: map[string]zapcore.Level{"db": zap.WarnLevel, "payments": zap.DebugLevel},
+		},
+		{
+			desc:          "PUT JSON without logger",
+			method:        http.MethodPut,
+			body:          `{"level":"error"}`,
+			expectedCode:  http.StatusOK,
+			expectedBody:  `{"logger":"","level":"error"}`,
+			expectedLevel: map[string]zapcore.Level{"": zap.ErrorLevel, "db": zap.WarnLevel},
+		},
+		{
+			desc:          "PUT URL encoded",
+			method:        http.MethodPut,
+			contentType:   "application/x-www-form-urlencoded",
+			body:          "level=debug&logger=db",
+			expectedCode:  http.StatusOK,
+			expectedBody:  `{"logger":"db","level":"debug"}`,
+			expectedLevel: map[string]zapcore.Level{"db": zap.DebugLevel},
+		},
+		{
+			desc:         "PUT JSON invalid duration",
+			method:       http.MethodPut,
+			body:         `{"level":"debug","logger":"db","for":"soon"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			desc:         "PUT JSON negative duration",
+			method:       http.MethodPut,
+			body:         `{"level":"debug","logger":"db","for":"-1m"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			desc:         "PUT URL encoded invalid duration",
+			method:       http.MethodPut,
+			contentType:  "application/x-www-form-urlencoded",
+			body:         "level=debug&for=soon",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			desc:         "PUT JSON unspecified",
+			method:       http.MethodPut,
+			body:         `{"logger":"db"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			desc:         "PUT JSON malformed",
+			method:       http.MethodPut,
+			body:         `{"level":"debug`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			desc:          "DELETE",
+			method:        http.MethodDelete,
+			query:         "?logger=db",
+			expectedCode:  http.StatusOK,
+			expectedBody:  `{"logger":"db","level":"info"}`,
+			expectedLevel: map[string]zapcore.Level{},
+		},
+		{
+			desc:         "DELETE without logger",
+			method:       http.MethodDelete,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			desc:         "POST",
+			method:       http.MethodPost,
+			body:         `{"level":"warn"}`,
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			levels := zap.NewNamedLevels(zap.NewAtomicLevelAt(zap.InfoLevel), map[string]zapcore.Level{
+				"db": zap.WarnLevel,
+			})
+
+			server := httptest.NewServer(levels)
+			defer server.Close()
+
+			req, err := http.NewRequest(tt.method, server.URL+tt.query, strings.NewReader(tt.body))
+			require.NoError(t, err, "Error constructing %s request.", tt.method)
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err, "Error making %s request.", tt.method)
+			defer func() {
+				assert.NoError(t, res.Body.Close(), "Error closing response body.")
+			}()
+
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err, "Error reading response body.")
+
+			require.Equal(t, tt.expectedCode, res.StatusCode, "Unexpected status code: %s", body)
+			if tt.expectedCode != http.StatusOK {
+				var pld struct {
+					Error string `json:"error"`
+				}
+				require.NoError(t, json.Unmarshal(body, &pld), "Decoding response body")
+				assert.NotEmpty(t, pld.Error, "Expected an error message")
+				return
+			}
+
+			assert.JSONEq(t, tt.expectedBody, string(body), "Unexpected response body.")
+			if tt.expectedLevel != nil {
+				assert.Equal(t, tt.expectedLevel, levels.Levels(), "Unexpected levels.")
+			}
+		})
+	}
+}
+
+func TestNamedLevelsServeHTTPTemporary(t *testing.T) {
+	levels := zap.NewNamedLevels(zap.NewAtomicLevelAt(zap.InfoLevel), nil)
+	server := httptest.NewServer(levels)
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPut, server.URL,
+		strings.NewReader(`{"level":"debug","logger":"payments","for":"50ms"}`))
+	require.NoError(t, err, "Error constructing request.")
+
+	before := time.Now()
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "Error making request.")
+	defer func() {
+		assert.NoError(t, res.Body.Close(), "Error closing response body.")
+	}()
+	require.Equal(t, http.StatusOK, res.StatusCode, "Unexpected status code.")
+
+	var pld struct {
+		Logger  string        `json:"logger"`
+		Level   zapcore.Level `json:"level"`
+		Expires time.Time     `json:"expires"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&pld), "Decoding response body")
+	assert.Equal(t, "payments", pld.Logger, "Unexpected logger.")
+	assert.Equal(t, zap.DebugLevel, pld.Level, "Unexpected level.")
+	assert.WithinDuration(t, before.Add(50*time.Millisecond), pld.Expires, time.Second, "Unexpected expiry.")
+	assert.True(t, levels.EnabledFor("payments.stripe", zap.DebugLevel), "Expected debug to be enabled.")
+
+	require.Eventually(t, func() bool {
+		return !levels.EnabledFor("payments.stripe", zap.DebugLevel)
+	}, time.Second, 5*time.Millisecond, "Expected level to revert.")
+	assert.Empty(t, levels.Levels(), "Expected temporary level to be removed.")
+}
+
+func TestNamedLevelsServeHTTPUninitialized(t *testing.T) {
+	request, err := http.NewRequest(http.MethodGet, "http://localhost:1234/log/level", nil)
+	require.NoError(t, err, "Error constructing request.")
+
+	recorder := httptest.NewRecorder()
+	zap.NamedLevels{}.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code, "Unexpected status code.")
+}
+
 func FuzzAtomicLevelServeHTTP(f *testing.F) {
 	f.Add(`{"level":"info"}`)
 	f.Add(`{"level":"warn"}`)
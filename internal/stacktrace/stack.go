@@ -24,6 +24,7 @@ package stacktrace
 
 import (
 	"runtime"
+	"strings"
 
 	"go.uber.org/zap/buffer"
 	"go.uber.org/zap/internal/bufferpool"
@@ -144,10 +145,84 @@ func Take(skip int) string {
 	return buffer.String()
 }
 
+// TakeWithFrames is like Take, but also returns the frames it formatted,
+// like TakeFrames.
+//
+// skip is the number of frames to skip before recording the stack trace.
+// skip=0 identifies the caller of TakeWithFrames.
+func TakeWithFrames(skip int) (string, []runtime.Frame) {
+	stack := Capture(skip+1, Full)
+	defer stack.Free()
+
+	buffer := bufferpool.Get()
+	defer buffer.Free()
+
+	stackfmt := NewFormatter(buffer)
+	stackfmt.RecordFrames(stack.Count())
+	stackfmt.FormatStack(stack)
+	return buffer.String(), stackfmt.Frames()
+}
+
+// TakeFrames returns the frames of the current stacktrace, minus the final
+// runtime.main/runtime.goexit frame, like Take.
+//
+// skip is the number of frames to skip before recording the stack trace.
+// skip=0 identifies the caller of TakeFrames.
+func TakeFrames(skip int) []runtime.Frame {
+	stack := Capture(skip+1, Full)
+	defer stack.Free()
+
+	frames := make([]runtime.Frame, 0, stack.Count())
+	for frame, more := stack.Next(); more; frame, more = stack.Next() {
+		frames = append(frames, frame)
+	}
+	return frames
+}
+
+// Filter selects the frames of a stack trace that a Formatter formats.
+// Frames are matched by package prefixes, as described by matchPackage.
+type Filter struct {
+	// Skip drops frames in packages matching any of these prefixes.
+	Skip []string
+
+	// Collapse keeps only the first of consecutive frames in packages
+	// matching the same one of these prefixes. Skipped frames don't
+	// interrupt a run.
+	Collapse []string
+
+	// MaxDepth, if positive, limits the number of frames formatted.
+	MaxDepth int
+}
+
+// matchPackage returns the first of prefixes that matches the package of
+// function, or "" if none do. A prefix matches its package and subpackages,
+// unless it ends with a period or slash, in which case it's compared as a
+// plain string prefix.
+func matchPackage(function string, prefixes []string) string {
+	for _, p := range prefixes {
+		if !strings.HasPrefix(function, p) {
+			continue
+		}
+		if len(function) == len(p) || strings.HasSuffix(p, ".") || strings.HasSuffix(p, "/") {
+			return p
+		}
+		if c := function[len(p)]; c == '.' || c == '/' {
+			return p
+		}
+	}
+	return ""
+}
+
 // Formatter formats a stack trace into a readable string representation.
 type Formatter struct {
 	b        *buffer.Buffer
 	nonEmpty bool // whehther we've written at least one frame already
+
+	filter     *Filter // may be nil
+	depth      int     // frames written so far
+	collapsing string  // Collapse prefix of the previous frame, if any
+
+	frames []runtime.Frame // frames written so far; nil unless recording
 }
 
 // NewFormatter builds a new Formatter.
@@ -155,19 +230,46 @@ func NewFormatter(b *buffer.Buffer) Formatter {
 	return Formatter{b: b}
 }
 
+// NewFilteredFormatter builds a new Formatter that only formats the frames
+// selected by the given Filter. A nil Filter selects all frames.
+func NewFilteredFormatter(b *buffer.Buffer, f *Filter) Formatter {
+	return Formatter{b: b, filter: f}
+}
+
+// RecordFrames makes the Formatter keep the frames it formats, so that
+// Frames can return them. n is the expected number of frames.
+func (sf *Formatter) RecordFrames(n int) {
+	sf.frames = make([]runtime.Frame, 0, n)
+}
+
+// Frames returns the frames formatted so far, or nil if RecordFrames
+// wasn't called.
+func (sf *Formatter) Frames() []runtime.Frame {
+	return sf.frames
+}
+
 // FormatStack formats all remaining frames in the provided stacktrace -- minus
 // the final runtime.main/runtime.goexit frame.
 func (sf *Formatter) FormatStack(stack *Stack) {
 	// Note: On the last iteration, frames.Next() returns false, with a valid
 	// frame, but we ignore this frame. The last frame is a runtime frame which
 	// adds noise, since it's only either runtime.main or runtime.goexit.
-	for frame, more := stack.Next(); more; frame, more = stack.Next() {
+	for frame, more := stack.Next(); more && !sf.full(); frame, more = stack.Next() {
 		sf.FormatFrame(frame)
 	}
 }
 
-// FormatFrame formats the given frame.
+// FormatFrame formats the given frame, unless the Formatter's Filter drops
+// it.
 func (sf *Formatter) FormatFrame(frame runtime.Frame) {
+	if sf.filter != nil && !sf.keep(frame) {
+		return
+	}
+	sf.depth++
+	if sf.frames != nil {
+		sf.frames = append(sf.frames, frame)
+	}
+
 	if sf.nonEmpty {
 		sf.b.AppendByte('\n')
 	}
@@ -179,3 +281,24 @@ func (sf *Formatter) FormatFrame(frame runtime.Frame) {
 	sf.b.AppendByte(':')
 	sf.b.AppendInt(int64(frame.Line))
 }
+
+// full reports whether the Formatter has written as many frames as its
+// Filter allows.
+func (sf *Formatter) full() bool {
+	return sf.filter != nil && sf.filter.MaxDepth > 0 && sf.depth >= sf.filter.MaxDepth
+}
+
+// keep reports whether the Formatter's Filter selects frame.
+func (sf *Formatter) keep(frame runtime.Frame) bool {
+	if matchPackage(frame.Function, sf.filter.Skip) != "" {
+		return false
+	}
+
+	prefix := matchPackage(frame.Function, sf.filter.Collapse)
+	if prefix != "" && prefix == sf.collapsing {
+		return false
+	}
+	sf.collapsing = prefix
+
+	return !sf.full()
+}
@@ -22,11 +22,14 @@ package stacktrace
 
 import (
 	"bytes"
+	"fmt"
+	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/internal/bufferpool"
 )
 
 func TestTake(t *testing.T) {
@@ -41,6 +44,25 @@ func TestTake(t *testing.T) {
 	)
 }
 
+func TestTakeFrames(t *testing.T) {
+	frames := TakeFrames(0)
+	require.NotEmpty(t, frames, "Expected stacktrace to have at least one frame.")
+	assert.Equal(t, "go.uber.org/zap/internal/stacktrace.TestTakeFrames", frames[0].Function,
+		"Expected stacktrace to start with the test.")
+	assert.True(t, strings.HasSuffix(frames[0].File, "stack_test.go"), "Unexpected file %q.", frames[0].File)
+	assert.Len(t, frames, len(strings.Split(Take(0), "\n"))/2, "Expected the same frames as Take.")
+}
+
+func TestTakeWithFrames(t *testing.T) {
+	text, frames := TakeWithFrames(0)
+	require.NotEmpty(t, frames, "Expected stacktrace to have at least one frame.")
+	assert.Len(t, frames, len(strings.Split(text, "\n"))/2, "Expected a frame for each formatted frame.")
+	assert.Equal(t, "go.uber.org/zap/internal/stacktrace.TestTakeWithFrames", frames[0].Function,
+		"Expected stacktrace to start with the test.")
+	assert.True(t, strings.HasPrefix(text, fmt.Sprintf("%s\n\t%s:%d\n", frames[0].Function, frames[0].File, frames[0].Line)),
+		"Expected the text to be formatted from the frames.")
+}
+
 func TestTakeWithSkip(t *testing.T) {
 	trace := Take(1)
 	lines := strings.Split(trace, "\n")
@@ -86,6 +108,102 @@ func TestTakeDeepStack(t *testing.T) {
 	})
 }
 
+func TestFilteredFormatter(t *testing.T) {
+	stack := []string{
+		"example.com/app.handle",
+		"runtime.gopanic",
+		"runtime/debug.Stack",
+		"github.com/gin-gonic/gin.(*Context).Next",
+		"github.com/gin-gonic/gin.Logger.func1",
+		"go.uber.org/zap.(*Logger).Error",
+		"github.com/gin-gonic/gin.(*Context).Next",
+		"github.com/gin-gonic/gin/render.Render",
+		"go.uber.org/zapfoo.Bar",
+		"net/http.(*conn).serve",
+	}
+
+	tests := []struct {
+		desc   string
+		filter *Filter
+		want   []string // functions of the formatted frames
+	}{
+		{
+			desc:   "nil filter",
+			filter: nil,
+			want:   stack,
+		},
+		{
+			desc:   "skip package and subpackages",
+			filter: &Filter{Skip: []string{"go.uber.org/zap", "github.com/gin-gonic/gin"}},
+			want:   []string{stack[0], stack[1], stack[2], stack[8], stack[9]},
+		},
+		{
+			desc:   "skip with trailing period",
+			filter: &Filter{Skip: []string{"runtime."}},
+			want:   []string{stack[0], stack[2], stack[3], stack[4], stack[5], stack[6], stack[7], stack[8], stack[9]},
+		},
+		{
+			desc:   "collapse",
+			filter: &Filter{Collapse: []string{"github.com/gin-gonic/gin"}},
+			want:   []string{stack[0], stack[1], stack[2], stack[3], stack[5], stack[6], stack[8], stack[9]},
+		},
+		{
+			desc: "skipped frames don't interrupt collapsed runs",
+			filter: &Filter{
+				Skip:     []string{"go.uber.org/zap"},
+				Collapse: []string{"github.com/gin-gonic/gin"},
+			},
+			want: []string{stack[0], stack[1], stack[2], stack[3], stack[8], stack[9]},
+		},
+		{
+			desc: "max depth",
+			filter: &Filter{
+				Skip:     []string{"runtime"},
+				MaxDepth: 2,
+			},
+			want: []string{stack[0], stack[3]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf := bufferpool.Get()
+			defer buf.Free()
+
+			sf := NewFilteredFormatter(buf, tt.filter)
+			for i, fn := range stack {
+				sf.FormatFrame(runtime.Frame{Function: fn, File: "f.go", Line: i})
+			}
+
+			var got []string
+			for _, line := range strings.Split(buf.String(), "\n") {
+				if !strings.HasPrefix(line, "\t") {
+					got = append(got, line)
+				}
+			}
+			assert.Equal(t, tt.want, got, "Unexpected frames.")
+		})
+	}
+}
+
+func TestFilteredFormatterStack(t *testing.T) {
+	buf := bufferpool.Get()
+	defer buf.Free()
+
+	stack := Capture(0, Full)
+	defer stack.Free()
+
+	sf := NewFilteredFormatter(buf, &Filter{MaxDepth: 1})
+	sf.RecordFrames(stack.Count())
+	sf.FormatStack(stack)
+	lines := strings.Split(buf.String(), "\n")
+	require.Len(t, lines, 2, "Expected exactly one frame.")
+	assert.Equal(t, "go.uber.org/zap/internal/stacktrace.TestFilteredFormatterStack", lines[0],
+		"Expected stacktrace to start with the test.")
+	require.Len(t, sf.Frames(), 1, "Expected the formatted frame to be recorded.")
+	assert.Equal(t, lines[0], sf.Frames()[0].Function, "Unexpected recorded frame.")
+}
+
 func BenchmarkTake(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		Take(0)
@@ -21,7 +21,11 @@
 package zap
 
 import (
+	"encoding/json"
+	"strings"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"go.uber.org/zap/internal"
 	"go.uber.org/zap/zapcore"
@@ -151,3 +155,379 @@ func (lvl *AtomicLevel) UnmarshalText(text []byte) error {
 func (lvl AtomicLevel) MarshalText() (text []byte, err error) {
 	return lvl.Level().MarshalText()
 }
+
+// NamedLevels is an atomically changeable set of logging levels keyed by
+// logger name, as built by Logger.Named. A level set for a name applies to
+// that logger and to every logger whose name starts with it followed by a
+// period; when several names match, the longest one wins. For example, with
+//
+//	{"": "info", "db": "warn", "db.pool": "debug"}
+//
+// loggers named "db" and "db.conn" log at WarnLevel, loggers named "db.pool"
+// and "db.pool.idle" log at DebugLevel, and all others log at InfoLevel.
+// Loggers whose names match none of the configured names use the root
+// AtomicLevel.
+//
+// Unlike IncreaseLevel, NamedLevels can enable a level for one logger that's
+// disabled for the rest. Pass NamedLevels to zapcore.NewCore in place of an
+// AtomicLevel, or set Config.Levels.
+//
+// NamedLevels must be created with NewNamedLevels, or unmarshaled from JSON
+// or YAML, to allocate their internal state. Copies share state. The zero
+// value has no levels set by name and panics if they're changed.
+type NamedLevels struct {
+	s *namedLevelState
+}
+
+var (
+	_ internal.LeveledEnabler   = NamedLevels{}
+	_ zapcore.NamedLevelEnabler = NamedLevels{}
+)
+
+type namedLevelState struct {
+	mu       sync.Mutex // serializes updates
+	snap     atomic.Pointer[namedLevelSnapshot]
+	expiries map[string]*namedLevelExpiry // guarded by mu
+}
+
+// namedLevelExpiry is a pending revert of a level set by SetTemporaryLevel.
+type namedLevelExpiry struct {
+	timer   *time.Timer
+	at      time.Time
+	restore *zapcore.Level // nil to unset the level
+}
+
+// namedLevelSnapshot is an immutable view of NamedLevels, replaced as a
+// whole on every change so that readers never take a lock.
+type namedLevelSnapshot struct {
+	root   AtomicLevel // zero if none; see namedLevelsWithRoot
+	levels map[string]zapcore.Level
+	min    zapcore.Level // lowest level in levels, or InvalidLevel if empty
+}
+
+// NewNamedLevels creates NamedLevels that fall back to root for loggers
+// whose names match none of the given levels.
+func NewNamedLevels(root AtomicLevel, levels map[string]zapcore.Level) NamedLevels {
+	nl := NamedLevels{s: &namedLevelState{}}
+	nl.s.snap.Store(newNamedLevelSnapshot(root, levels))
+	return nl
+}
+
+func newNamedLevelSnapshot(root AtomicLevel, levels map[string]zapcore.Level) *namedLevelSnapshot {
+	snap := &namedLevelSnapshot{
+		root:   root,
+		levels: make(map[string]zapcore.Level, len(levels)),
+		min:    zapcore.InvalidLevel,
+	}
+	for name, lvl := range levels {
+		snap.levels[name] = lvl
+		if lvl < snap.min {
+			snap.min = lvl
+		}
+	}
+	return snap
+}
+
+func (nl NamedLevels) load() *namedLevelSnapshot {
+	if nl.s == nil {
+		return &namedLevelSnapshot{min: zapcore.InvalidLevel}
+	}
+	return nl.s.snap.Load()
+}
+
+// mustState returns nl's state for a change, panicking with an explanation
+// rather than a nil pointer dereference for the zero value.
+func (nl NamedLevels) mustState() *namedLevelState {
+	if nl.s == nil {
+		panic("zap: NamedLevels must be created with NewNamedLevels before levels can be set")
+	}
+	return nl.s
+}
+
+// update replaces the snapshot with the result of fn, which receives a copy
+// of the current levels that it may modify.
+func (nl NamedLevels) update(fn func(root AtomicLevel, levels map[string]zapcore.Level) AtomicLevel) {
+	nl.mustState().mu.Lock()
+	defer nl.s.mu.Unlock()
+
+	nl.updateLocked(fn)
+}
+
+// updateLocked is like update, but requires nl.s.mu to be held.
+func (nl NamedLevels) updateLocked(fn func(root AtomicLevel, levels map[string]zapcore.Level) AtomicLevel) {
+	old := nl.s.snap.Load()
+	levels := make(map[string]zapcore.Level, len(old.levels))
+	for name, lvl := range old.levels {
+		levels[name] = lvl
+	}
+	root := fn(old.root, levels)
+	nl.s.snap.Store(newNamedLevelSnapshot(root, levels))
+}
+
+// rootLevel returns the level of the root AtomicLevel, falling back to the
+// given one and then to InfoLevel if there isn't one.
+func (snap *namedLevelSnapshot) rootLevel(fallback AtomicLevel) zapcore.Level {
+	root := snap.root
+	if root == (AtomicLevel{}) {
+		root = fallback
+	}
+	if root == (AtomicLevel{}) {
+		return InfoLevel
+	}
+	return root.Level()
+}
+
+// level returns the minimum level enabled for any logger.
+func (snap *namedLevelSnapshot) level(fallback AtomicLevel) zapcore.Level {
+	if root := snap.rootLevel(fallback); root < snap.min {
+		return root
+	}
+	return snap.min
+}
+
+// levelFor returns the level that applies to the logger with the given
+// name.
+func (snap *namedLevelSnapshot) levelFor(name string, fallback AtomicLevel) zapcore.Level {
+	if len(snap.levels) > 0 {
+		for prefix := name; ; {
+			if lvl, ok := snap.levels[prefix]; ok {
+				return lvl
+			}
+			if prefix == "" {
+				break
+			}
+			if i := strings.LastIndexByte(prefix, '.'); i >= 0 {
+				prefix = prefix[:i]
+			} else {
+				prefix = ""
+			}
+		}
+	}
+	return snap.rootLevel(fallback)
+}
+
+// Enabled reports whether the level is enabled for any logger.
+func (nl NamedLevels) Enabled(l zapcore.Level) bool {
+	return nl.Level().Enabled(l)
+}
+
+// Level returns the minimum level enabled for any logger.
+func (nl NamedLevels) Level() zapcore.Level {
+	return nl.load().level(AtomicLevel{})
+}
+
+// EnabledFor reports whether the level is enabled for the logger with the
+// given name.
+func (nl NamedLevels) EnabledFor(name string, l zapcore.Level) bool {
+	return nl.LevelFor(name).Enabled(l)
+}
+
+// LevelFor returns the level that applies to the logger with the given name.
+func (nl NamedLevels) LevelFor(name string) zapcore.Level {
+	return nl.load().levelFor(name, AtomicLevel{})
+}
+
+// namedLevelsWithRoot falls back to root for NamedLevels created without a
+// root AtomicLevel, such as those unmarshaled from a Config. Config.Build
+// wraps its Levels in one for every Core rather than storing its Level in
+// them, so that building a Config again with a different Level takes
+// effect.
+type namedLevelsWithRoot struct {
+	levels NamedLevels
+	root   AtomicLevel
+}
+
+var _ zapcore.NamedLevelEnabler = namedLevelsWithRoot{}
+
+func (e namedLevelsWithRoot) Enabled(l zapcore.Level) bool {
+	return e.Level().Enabled(l)
+}
+
+func (e namedLevelsWithRoot) Level() zapcore.Level {
+	return e.levels.load().level(e.root)
+}
+
+func (e namedLevelsWithRoot) EnabledFor(name string, l zapcore.Level) bool {
+	return e.levels.load().levelFor(name, e.root).Enabled(l)
+}
+
+// SetLevel sets the level for the logger with the given name and its
+// descendants. Use the empty name to set the level of all loggers that
+// don't have a more specific one. It cancels any pending revert scheduled
+// by SetTemporaryLevel for the name.
+func (nl NamedLevels) SetLevel(name string, l zapcore.Level) {
+	nl.mustState().mu.Lock()
+	defer nl.s.mu.Unlock()
+
+	nl.cancelExpiryLocked(name)
+	nl.setLocked(name, &l)
+}
+
+// UnsetLevel removes the level set for the given name, so that the logger
+// with that name uses the level of its closest configured ancestor. It
+// cancels any pending revert scheduled by SetTemporaryLevel for the name.
+func (nl NamedLevels) UnsetLevel(name string) {
+	nl.mustState().mu.Lock()
+	defer nl.s.mu.Unlock()
+
+	nl.cancelExpiryLocked(name)
+	nl.setLocked(name, nil)
+}
+
+// SetTemporaryLevel sets the level for the logger with the given name, like
+// SetLevel, and reverts it once ttl has passed. The level reverts to the one
+// set for the name before any temporary levels, or is unset if there wasn't
+// one. Calling SetLevel or UnsetLevel for the name before then makes the
+// change permanent.
+//
+// This is useful to turn on debug logging for a subsystem without having to
+// remember to turn it off again.
+func (nl NamedLevels) SetTemporaryLevel(name string, l zapcore.Level, ttl time.Duration) {
+	nl.mustState().mu.Lock()
+	defer nl.s.mu.Unlock()
+
+	var restore *zapcore.Level
+	if prev := nl.cancelExpiryLocked(name); prev != nil {
+		restore = prev.restore
+	} else if cur, ok := nl.s.snap.Load().levels[name]; ok {
+		restore = &cur
+	}
+
+	exp := &namedLevelExpiry{at: time.Now().Add(ttl), restore: restore}
+	exp.timer = time.AfterFunc(ttl, func() { nl.expire(name, exp) })
+	if nl.s.expiries == nil {
+		nl.s.expiries = make(map[string]*namedLevelExpiry)
+	}
+	nl.s.expiries[name] = exp
+	nl.setLocked(name, &l)
+}
+
+// Expirations returns the times at which levels set with SetTemporaryLevel
+// will revert, keyed by name.
+func (nl NamedLevels) Expirations() map[string]time.Time {
+	if nl.s == nil {
+		return map[string]time.Time{}
+	}
+
+	nl.s.mu.Lock()
+	defer nl.s.mu.Unlock()
+
+	exps := make(map[string]time.Time, len(nl.s.expiries))
+	for name, exp := range nl.s.expiries {
+		exps[name] = exp.at
+	}
+	return exps
+}
+
+// expire reverts a temporary level, unless it has since been replaced.
+func (nl NamedLevels) expire(name string, exp *namedLevelExpiry) {
+	nl.s.mu.Lock()
+	defer nl.s.mu.Unlock()
+
+	if nl.s.expiries[name] != exp {
+		return
+	}
+	delete(nl.s.expiries, name)
+	nl.setLocked(name, exp.restore)
+}
+
+// cancelExpiryLocked stops any pending revert for the name and returns it.
+// nl.s.mu must be held.
+func (nl NamedLevels) cancelExpiryLocked(name string) *namedLevelExpiry {
+	exp, ok := nl.s.expiries[name]
+	if !ok {
+		return nil
+	}
+	exp.timer.Stop()
+	delete(nl.s.expiries, name)
+	return exp
+}
+
+// setLocked sets the level for the name, or unsets it if l is nil.
+// nl.s.mu must be held.
+func (nl NamedLevels) setLocked(name string, l *zapcore.Level) {
+	nl.updateLocked(func(root AtomicLevel, levels map[string]zapcore.Level) AtomicLevel {
+		if l == nil {
+			delete(levels, name)
+		} else {
+			levels[name] = *l
+		}
+		return root
+	})
+}
+
+// Levels returns a copy of the levels set by name. It doesn't include the
+// root AtomicLevel.
+func (nl NamedLevels) Levels() map[string]zapcore.Level {
+	snap := nl.load()
+	levels := make(map[string]zapcore.Level, len(snap.levels))
+	for name, lvl := range snap.levels {
+		levels[name] = lvl
+	}
+	return levels
+}
+
+// isZero reports whether the NamedLevels were never initialized.
+func (nl NamedLevels) isZero() bool {
+	return nl.s == nil
+}
+
+// set replaces all levels set by name, allocating state if necessary. It
+// cancels all pending reverts.
+func (nl *NamedLevels) set(levels map[string]zapcore.Level) {
+	if nl.s == nil {
+		*nl = NewNamedLevels(AtomicLevel{}, levels)
+		return
+	}
+
+	nl.s.mu.Lock()
+	defer nl.s.mu.Unlock()
+
+	for name := range nl.s.expiries {
+		nl.cancelExpiryLocked(name)
+	}
+	nl.updateLocked(func(root AtomicLevel, old map[string]zapcore.Level) AtomicLevel {
+		for name := range old {
+			delete(old, name)
+		}
+		for name, lvl := range levels {
+			old[name] = lvl
+		}
+		return root
+	})
+}
+
+// MarshalJSON marshals the levels set by name as a JSON object.
+func (nl NamedLevels) MarshalJSON() ([]byte, error) {
+	return json.Marshal(nl.Levels())
+}
+
+// MarshalYAML marshals the levels set by name as a YAML mapping.
+func (nl NamedLevels) MarshalYAML() (interface{}, error) {
+	levels := make(map[string]string)
+	for name, lvl := range nl.Levels() {
+		levels[name] = lvl.String()
+	}
+	return levels, nil
+}
+
+// UnmarshalJSON unmarshals a JSON object mapping logger names to levels,
+// such as {"": "info", "db": "warn"}.
+func (nl *NamedLevels) UnmarshalJSON(data []byte) error {
+	var levels map[string]zapcore.Level
+	if err := json.Unmarshal(data, &levels); err != nil {
+		return err
+	}
+	nl.set(levels)
+	return nil
+}
+
+// UnmarshalYAML unmarshals a YAML mapping of logger names to levels.
+func (nl *NamedLevels) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var levels map[string]zapcore.Level
+	if err := unmarshal(&levels); err != nil {
+		return err
+	}
+	nl.set(levels)
+	return nil
+}
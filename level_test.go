@@ -21,10 +21,14 @@
 package zap
 
 import (
+	"encoding/json"
 	"sync"
 	"testing"
+	"time"
 
+	"go.uber.org/zap/internal/ztest"
 	"go.uber.org/zap/zapcore"
+	"go.yaml.in/yaml/v3"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -138,3 +142,155 @@ func TestAtomicLevelText(t *testing.T) {
 		}
 	}
 }
+
+func TestNamedLevels(t *testing.T) {
+	root := NewAtomicLevelAt(ErrorLevel)
+	levels := NewNamedLevels(root, map[string]zapcore.Level{
+		"":        InfoLevel,
+		"db":      WarnLevel,
+		"db.pool": DebugLevel,
+	})
+
+	tests := []struct {
+		name string
+		want zapcore.Level
+	}{
+		{"", InfoLevel},
+		{"http", InfoLevel},
+		{"db", WarnLevel},
+		{"db.conn", WarnLevel},
+		{"dbx", InfoLevel},
+		{"db.pool", DebugLevel},
+		{"db.pool.idle", DebugLevel},
+		{"db.poolside", WarnLevel},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, levels.LevelFor(tt.name), "Unexpected level for %q.", tt.name)
+		assert.True(t, levels.EnabledFor(tt.name, tt.want), "Expected %v to be enabled for %q.", tt.want, tt.name)
+		assert.False(t, levels.EnabledFor(tt.name, tt.want-1), "Expected %v to be disabled for %q.", tt.want-1, tt.name)
+	}
+
+	assert.Equal(t, DebugLevel, levels.Level(), "Expected the lowest level of any logger.")
+	assert.True(t, levels.Enabled(DebugLevel), "Expected debug to be enabled for some logger.")
+
+	levels.UnsetLevel("")
+	assert.Equal(t, ErrorLevel, levels.LevelFor("http"), "Expected unmatched names to use the root level.")
+	root.SetLevel(WarnLevel)
+	assert.Equal(t, WarnLevel, levels.LevelFor("http"), "Expected root level changes to apply.")
+
+	levels.SetLevel("db.pool", ErrorLevel)
+	assert.Equal(t, ErrorLevel, levels.LevelFor("db.pool.idle"), "Expected updated level.")
+	assert.Equal(t, WarnLevel, levels.Level(), "Expected minimum level to be recomputed.")
+	assert.Equal(t, map[string]zapcore.Level{"db": WarnLevel, "db.pool": ErrorLevel}, levels.Levels(), "Unexpected levels.")
+}
+
+func TestNamedLevelsZeroValue(t *testing.T) {
+	var levels NamedLevels
+	assert.Equal(t, InfoLevel, levels.LevelFor("db"), "Expected the zero value to use InfoLevel.")
+	assert.Empty(t, levels.Levels(), "Expected no levels set by name.")
+	assert.Empty(t, levels.Expirations(), "Expected no expirations.")
+	assert.PanicsWithValue(t, "zap: NamedLevels must be created with NewNamedLevels before levels can be set",
+		func() { levels.SetLevel("db", DebugLevel) }, "Expected a clear panic setting levels on the zero value.")
+}
+
+func TestNamedLevelsLogger(t *testing.T) {
+	levels := NewNamedLevels(NewAtomicLevelAt(InfoLevel), map[string]zapcore.Level{
+		"db":      ErrorLevel,
+		"db.pool": DebugLevel,
+	})
+	sink := &ztest.Buffer{}
+	logger := New(zapcore.NewCore(zapcore.NewConsoleEncoder(zapcore.EncoderConfig{MessageKey: "M", NameKey: "N"}), sink, levels))
+
+	logger.Debug("root debug")
+	logger.Info("root info")
+	logger.Named("db").Warn("db warn")
+	logger.Named("db").Error("db error")
+	logger.Named("db").Named("pool").Debug("pool debug")
+	assert.Equal(t, []string{"root info", "db\tdb error", "db.pool\tpool debug"}, sink.Lines(), "Unexpected output.")
+	assert.Equal(t, DebugLevel, logger.Level(), "Expected logger level to be the lowest named level.")
+}
+
+func TestNamedLevelsConcurrent(t *testing.T) {
+	levels := NewNamedLevels(NewAtomicLevel(), nil)
+	proceed := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	runConcurrently(10, 100, wg, func() {
+		<-proceed
+		levels.EnabledFor("a.b", DebugLevel)
+	})
+	runConcurrently(10, 100, wg, func() {
+		<-proceed
+		levels.SetLevel("a", DebugLevel)
+	})
+	close(proceed)
+	wg.Wait()
+	assert.Equal(t, DebugLevel, levels.LevelFor("a.b"))
+}
+
+func TestNamedLevelsTemporary(t *testing.T) {
+	t.Run("reverts to previous level", func(t *testing.T) {
+		levels := NewNamedLevels(NewAtomicLevelAt(InfoLevel), map[string]zapcore.Level{"db": WarnLevel})
+		levels.SetTemporaryLevel("db", DebugLevel, 10*time.Millisecond)
+		levels.SetTemporaryLevel("db", ErrorLevel, 20*time.Millisecond)
+		assert.Equal(t, ErrorLevel, levels.LevelFor("db"), "Expected temporary level.")
+		assert.Contains(t, levels.Expirations(), "db", "Expected pending revert.")
+
+		require.Eventually(t, func() bool {
+			return levels.LevelFor("db") == WarnLevel
+		}, time.Second, time.Millisecond, "Expected level set before any temporary levels.")
+		assert.Empty(t, levels.Expirations(), "Expected no pending reverts.")
+	})
+
+	t.Run("unsets new level", func(t *testing.T) {
+		levels := NewNamedLevels(NewAtomicLevelAt(InfoLevel), nil)
+		levels.SetTemporaryLevel("db", DebugLevel, time.Millisecond)
+		require.Eventually(t, func() bool {
+			return len(levels.Levels()) == 0
+		}, time.Second, time.Millisecond, "Expected temporary level to be unset.")
+	})
+
+	t.Run("SetLevel makes permanent", func(t *testing.T) {
+		levels := NewNamedLevels(NewAtomicLevelAt(InfoLevel), nil)
+		levels.SetTemporaryLevel("db", DebugLevel, time.Millisecond)
+		levels.SetLevel("db", ErrorLevel)
+		assert.Empty(t, levels.Expirations(), "Expected no pending reverts.")
+		time.Sleep(5 * time.Millisecond)
+		assert.Equal(t, ErrorLevel, levels.LevelFor("db"), "Expected permanent level.")
+	})
+
+	t.Run("unmarshal cancels", func(t *testing.T) {
+		levels := NewNamedLevels(NewAtomicLevelAt(InfoLevel), nil)
+		levels.SetTemporaryLevel("db", DebugLevel, time.Hour)
+		require.NoError(t, json.Unmarshal([]byte(`{"http":"warn"}`), &levels))
+		assert.Empty(t, levels.Expirations(), "Expected no pending reverts.")
+	})
+}
+
+func TestNamedLevelsSerialization(t *testing.T) {
+	const (
+		jsonDoc = `{"":"info","db":"warn","db.pool":"debug"}`
+		yamlDoc = "\"\": info\ndb: warn\ndb.pool: debug\n"
+	)
+	want := map[string]zapcore.Level{"": InfoLevel, "db": WarnLevel, "db.pool": DebugLevel}
+
+	var fromJSON NamedLevels
+	require.NoError(t, json.Unmarshal([]byte(jsonDoc), &fromJSON), "Failed to unmarshal JSON.")
+	assert.Equal(t, want, fromJSON.Levels(), "Unexpected levels from JSON.")
+	marshaled, err := json.Marshal(fromJSON)
+	require.NoError(t, err, "Failed to marshal JSON.")
+	assert.JSONEq(t, jsonDoc, string(marshaled), "Unexpected JSON.")
+
+	var fromYAML NamedLevels
+	require.NoError(t, yaml.Unmarshal([]byte(yamlDoc), &fromYAML), "Failed to unmarshal YAML.")
+	assert.Equal(t, want, fromYAML.Levels(), "Unexpected levels from YAML.")
+	marshaled, err = yaml.Marshal(fromYAML)
+	require.NoError(t, err, "Failed to marshal YAML.")
+	assert.YAMLEq(t, yamlDoc, string(marshaled), "Unexpected YAML.")
+
+	// Unmarshaling again replaces the levels without replacing the state.
+	handle := fromJSON
+	require.NoError(t, json.Unmarshal([]byte(`{"http":"error"}`), &fromJSON))
+	assert.Equal(t, map[string]zapcore.Level{"http": ErrorLevel}, handle.Levels(), "Expected copies to share state.")
+
+	assert.Error(t, json.Unmarshal([]byte(`{"db":"loud"}`), &fromJSON), "Expected invalid levels to fail.")
+}
@@ -21,11 +21,14 @@
 package zap
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"strings"
+	"sync"
 
+	"go.uber.org/multierr"
 	"go.uber.org/zap/internal/bufferpool"
 	"go.uber.org/zap/internal/stacktrace"
 	"go.uber.org/zap/zapcore"
@@ -49,11 +52,17 @@ type Logger struct {
 	name        string
 	errorOutput zapcore.WriteSyncer
 
-	addStack zapcore.LevelEnabler
+	addStack    zapcore.LevelEnabler
+	stackFilter *stacktrace.Filter // nil keeps every frame
 
 	callerSkip int
 
 	clock zapcore.Clock
+
+	ctx           context.Context                 // set by Ctx; nil if none
+	ctxExtractors []func(context.Context) []Field // see WithContextExtractor
+
+	closers *closerList // shared with derived loggers; nil if there's nothing to close
 }
 
 // New constructs a new Logger from the provided zapcore.Core and Options. If
@@ -186,6 +195,20 @@ func (log *Logger) With(fields ...Field) *Logger {
 	return l
 }
 
+// Ctx returns a child logger that attaches fields taken from ctx to every
+// entry it writes. The fields are produced by the extractors registered with
+// the WithContextExtractor option, which run only for entries that will be
+// written. Passing a nil context detaches any previously set context.
+//
+//	func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+//		h.logger.Ctx(r.Context()).Info("handling request")
+//	}
+func (log *Logger) Ctx(ctx context.Context) *Logger {
+	l := log.clone()
+	l.ctx = ctx
+	return l
+}
+
 // WithLazy creates a child logger and adds structured context to it lazily.
 //
 // The fields are evaluated only if the logger is further chained with [With]
@@ -303,6 +326,26 @@ func (log *Logger) Sync() error {
 	return log.core.Sync()
 }
 
+// Close syncs the Logger, then releases the resources it owns: the sinks
+// opened by Config.Build, background goroutines started by options such as
+// ReopenSinksOnSignal, and anything registered with OnClose. Resources are
+// released in the reverse of the order in which they were acquired.
+//
+// These resources are shared by every Logger derived from the same root
+// with methods like With and Named, so closing any of them closes all of
+// them. Don't log to any of them after calling Close. Calling Close more
+// than once is safe; subsequent calls only sync the Logger.
+//
+// Loggers built with New own nothing unless options give them something to
+// own, so for those Close is equivalent to Sync.
+func (log *Logger) Close() error {
+	err := log.Sync()
+	if log.closers != nil {
+		err = multierr.Append(err, log.closers.close())
+	}
+	return err
+}
+
 // Core returns the Logger's underlying zapcore.Core.
 func (log *Logger) Core() zapcore.Core {
 	return log.core
@@ -314,6 +357,55 @@ func (log *Logger) Name() string {
 	return log.name
 }
 
+// onClose registers fn to be called when log, or any Logger that shares its
+// resources, is closed.
+func (log *Logger) onClose(fn func() error) {
+	if log.closers == nil {
+		log.closers = &closerList{}
+	}
+	log.closers.add(fn)
+}
+
+// closerList holds the functions that release a Logger's resources.
+type closerList struct {
+	mu  sync.Mutex
+	fns []func() error
+}
+
+func (cl *closerList) add(fn func() error) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	cl.fns = append(cl.fns, fn)
+}
+
+// close calls each function in reverse order, once.
+func (cl *closerList) close() error {
+	cl.mu.Lock()
+	fns := cl.fns
+	cl.fns = nil
+	cl.mu.Unlock()
+
+	var err error
+	for i := len(fns) - 1; i >= 0; i-- {
+		err = multierr.Append(err, fns[i]())
+	}
+	return err
+}
+
+// addContextFields is a zapcore.CheckPreWriteHook that prepends the fields
+// extracted from the logger's context to those passed at the log site.
+func (log *Logger) addContextFields(ent zapcore.Entry, fields []Field) (zapcore.Entry, []Field) {
+	var ctxFields []Field
+	for _, extract := range log.ctxExtractors {
+		ctxFields = append(ctxFields, extract(log.ctx)...)
+	}
+	if len(ctxFields) == 0 {
+		return ent, fields
+	}
+	return ent, append(ctxFields, fields...)
+}
+
 func (log *Logger) clone() *Logger {
 	clone := *log
 	return &clone
@@ -365,6 +457,10 @@ func (log *Logger) check(lvl zapcore.Level, msg string) *zapcore.CheckedEntry {
 	// Thread the error output through to the CheckedEntry.
 	ce.ErrorOutput = log.errorOutput
 
+	if log.ctx != nil && len(log.ctxExtractors) > 0 {
+		ce = ce.Before(ent, log.addContextFields)
+	}
+
 	addStack := log.addStack.Enabled(ce.Level)
 	if !log.addCaller && !addStack {
 		return ce
@@ -407,7 +503,8 @@ func (log *Logger) check(lvl zapcore.Level, msg string) *zapcore.CheckedEntry {
 		buffer := bufferpool.Get()
 		defer buffer.Free()
 
-		stackfmt := stacktrace.NewFormatter(buffer)
+		stackfmt := stacktrace.NewFilteredFormatter(buffer, log.stackFilter)
+		stackfmt.RecordFrames(stack.Count())
 
 		// We've already extracted the first frame, so format that
 		// separately and defer to stackfmt for the rest.
@@ -416,6 +513,7 @@ func (log *Logger) check(lvl zapcore.Level, msg string) *zapcore.CheckedEntry {
 			stackfmt.FormatStack(stack)
 		}
 		ce.Stack = buffer.String()
+		ce.StackFrames = stackfmt.Frames()
 	}
 
 	return ce
@@ -21,6 +21,7 @@
 package zap
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -624,6 +625,109 @@ func TestLoggerSyncFail(t *testing.T) {
 	assert.Equal(t, err, logger.Sugar().Sync(), "Expected SugaredLogger.Sync to propagate errors.")
 }
 
+func TestLoggerClose(t *testing.T) {
+	t.Run("without resources", func(t *testing.T) {
+		sink := &ztest.Buffer{}
+		logger := New(zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{}), sink, DebugLevel))
+		assert.NoError(t, logger.Close(), "Expected Close to succeed.")
+		assert.True(t, sink.Called(), "Expected Close to sync the core.")
+	})
+
+	t.Run("OnClose", func(t *testing.T) {
+		var calls []string
+		closer := func(name string, err error) Option {
+			return OnClose(func() error {
+				calls = append(calls, name)
+				return err
+			})
+		}
+
+		root := New(zapcore.NewNopCore(), closer("first", nil))
+		child := root.With(String("k", "v")).WithOptions(closer("second", errors.New("second failed")))
+
+		assert.ErrorContains(t, child.Close(), "second failed", "Expected errors from closers to propagate.")
+		assert.Equal(t, []string{"second", "first"}, calls, "Expected closers to run in reverse order.")
+
+		assert.NoError(t, root.Close(), "Expected closing again to succeed.")
+		assert.Len(t, calls, 2, "Expected closers to run only once.")
+	})
+}
+
+func TestLoggerCtx(t *testing.T) {
+	type ctxKey struct{}
+	requestID := func(ctx context.Context) []Field {
+		if id, ok := ctx.Value(ctxKey{}).(string); ok {
+			return []Field{String("request_id", id)}
+		}
+		return nil
+	}
+	tenant := func(context.Context) []Field {
+		return []Field{String("tenant", "acme")}
+	}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "42")
+
+	t.Run("extractors", func(t *testing.T) {
+		withLogger(t, DebugLevel, opts(WithContextExtractor(requestID), WithContextExtractor(tenant)), func(logger *Logger, logs *observer.ObservedLogs) {
+			logger.Info("no context")
+			logger.Ctx(ctx).With(Int("with", 1)).Info("context", Int("site", 2))
+			logger.Ctx(context.Background()).Sugar().Infow("sugared", "site", 3)
+			var detached context.Context // nil detaches the context
+			logger.Ctx(ctx).Ctx(detached).Info("detached")
+			if ce := logger.Ctx(ctx).Check(InfoLevel, "checked"); ce != nil {
+				ce.Write()
+			}
+
+			assert.Equal(t, []observer.LoggedEntry{
+				{
+					Entry:   zapcore.Entry{Level: InfoLevel, Message: "no context"},
+					Context: []Field{},
+				},
+				{
+					Entry:   zapcore.Entry{Level: InfoLevel, Message: "context"},
+					Context: []Field{Int("with", 1), String("request_id", "42"), String("tenant", "acme"), Int("site", 2)},
+				},
+				{
+					Entry:   zapcore.Entry{Level: InfoLevel, Message: "sugared"},
+					Context: []Field{String("tenant", "acme"), Int("site", 3)},
+				},
+				{
+					Entry:   zapcore.Entry{Level: InfoLevel, Message: "detached"},
+					Context: []Field{},
+				},
+				{
+					Entry:   zapcore.Entry{Level: InfoLevel, Message: "checked"},
+					Context: []Field{String("request_id", "42"), String("tenant", "acme")},
+				},
+			}, logs.AllUntimed(), "Unexpected context fields.")
+		})
+	})
+
+	t.Run("disabled levels skip extraction", func(t *testing.T) {
+		var calls int
+		counting := func(context.Context) []Field {
+			calls++
+			return nil
+		}
+		withLogger(t, InfoLevel, opts(WithContextExtractor(counting)), func(logger *Logger, logs *observer.ObservedLogs) {
+			logger.Ctx(ctx).Debug("dropped")
+			assert.Zero(t, calls, "Expected extractors not to run for disabled levels.")
+		})
+	})
+
+	t.Run("children don't share extractors", func(t *testing.T) {
+		withLogger(t, DebugLevel, opts(WithContextExtractor(requestID)), func(logger *Logger, logs *observer.ObservedLogs) {
+			parent := logger.WithOptions(WithContextExtractor(tenant))
+			parent.WithOptions(WithContextExtractor(requestID)).Ctx(ctx).Info("child")
+			parent.WithOptions(WithContextExtractor(tenant)).Ctx(ctx).Info("sibling")
+
+			entries := logs.AllUntimed()
+			require.Len(t, entries, 2, "Unexpected number of entries.")
+			assert.Equal(t, []Field{String("request_id", "42"), String("tenant", "acme"), String("request_id", "42")}, entries[0].Context)
+			assert.Equal(t, []Field{String("request_id", "42"), String("tenant", "acme"), String("tenant", "acme")}, entries[1].Context)
+		})
+	})
+}
+
 func TestLoggerAddCaller(t *testing.T) {
 	tests := []struct {
 		options []Option
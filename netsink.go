@@ -0,0 +1,413 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	schemeTCP = "tcp"
+	schemeUDP = "udp"
+
+	_netDefaultQueueSize  = 1024
+	_netDefaultMinBackoff = 100 * time.Millisecond
+	_netDefaultMaxBackoff = 30 * time.Second
+	_netDefaultTimeout    = 5 * time.Second
+)
+
+// netOverflow selects what a network sink does with a new entry when its
+// queue is full.
+type netOverflow int
+
+const (
+	netDropOldest netOverflow = iota
+	netDropNewest
+	netBlock
+)
+
+func parseNetOverflow(s string) (netOverflow, error) {
+	switch s {
+	case "dropOldest":
+		return netDropOldest, nil
+	case "dropNewest":
+		return netDropNewest, nil
+	case "block":
+		return netBlock, nil
+	}
+	return 0, fmt.Errorf("unknown overflow policy %q", s)
+}
+
+// netConfig holds the options parsed from a "tcp" or "udp" URL.
+type netConfig struct {
+	network    string
+	address    string
+	queueSize  int
+	overflow   netOverflow
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	timeout    time.Duration
+}
+
+// newNetSinkFromURL builds a reconnecting network sink from a URL of the
+// form tcp://host:port or udp://host:port. See Open for the supported
+// parameters.
+func newNetSinkFromURL(u *url.URL) (Sink, error) {
+	if u.User != nil {
+		return nil, fmt.Errorf("user and password not allowed with %v URLs: got %v", u.Scheme, u)
+	}
+	if u.Fragment != "" {
+		return nil, fmt.Errorf("fragments not allowed with %v URLs: got %v", u.Scheme, u)
+	}
+	if u.Path != "" && u.Path != "/" {
+		return nil, fmt.Errorf("paths not allowed with %v URLs: got %v", u.Scheme, u)
+	}
+	if u.Hostname() == "" || u.Port() == "" {
+		return nil, fmt.Errorf("%v URLs must specify a host and port: got %v", u.Scheme, u)
+	}
+
+	cfg, err := parseNetQuery(u.Query())
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v URL %v: %w", u.Scheme, u, err)
+	}
+	cfg.network = u.Scheme
+	cfg.address = u.Host
+	return newNetSink(cfg, net.DialTimeout), nil
+}
+
+func parseNetQuery(q url.Values) (netConfig, error) {
+	var (
+		cfg = netConfig{
+			queueSize:  _netDefaultQueueSize,
+			overflow:   netDropOldest,
+			minBackoff: _netDefaultMinBackoff,
+			maxBackoff: _netDefaultMaxBackoff,
+			timeout:    _netDefaultTimeout,
+		}
+		err error
+	)
+	for key, vals := range q {
+		if len(vals) != 1 {
+			return cfg, fmt.Errorf("parameter %q must be specified exactly once", key)
+		}
+		val := vals[0]
+		switch key {
+		case "queueSize":
+			cfg.queueSize, err = strconv.Atoi(val)
+			if err == nil && cfg.queueSize <= 0 {
+				err = errors.New("must be positive")
+			}
+		case "overflow":
+			cfg.overflow, err = parseNetOverflow(val)
+		case "minBackoff":
+			cfg.minBackoff, err = parsePositiveDuration(val)
+		case "maxBackoff":
+			cfg.maxBackoff, err = parsePositiveDuration(val)
+		case "timeout":
+			cfg.timeout, err = parsePositiveDuration(val)
+		default:
+			return cfg, fmt.Errorf("unknown parameter %q", key)
+		}
+		if err != nil {
+			return cfg, fmt.Errorf("can't parse %q parameter %q: %v", key, val, err)
+		}
+	}
+	if cfg.maxBackoff < cfg.minBackoff {
+		return cfg, errors.New("maxBackoff must not be less than minBackoff")
+	}
+	return cfg, nil
+}
+
+// netSink is a Sink that writes entries to a TCP or UDP peer from a
+// background goroutine.
+//
+// Entries are queued in memory and sent in order. If the connection fails,
+// the send loop holds on to the entry it was sending and redials with
+// exponential backoff. While disconnected, the queue holds at most
+// queueSize entries and the overflow policy decides what happens to the rest.
+type netSink struct {
+	cfg  netConfig
+	dial func(network, address string, timeout time.Duration) (net.Conn, error)
+
+	mu       sync.Mutex
+	cond     *sync.Cond // signaled whenever the state below changes
+	queue    [][]byte   // ring buffer of pending entries
+	head, n  int        // index of the oldest entry and number of entries
+	inFlight bool       // whether the send loop holds an unsent entry
+	lastErr  error      // most recent send error; nil while connected
+	dropped  int        // entries dropped since the last Sync
+	closed   bool
+
+	stop chan struct{} // closed when the send loop should stop retrying
+	done chan struct{} // closed when the send loop has exited
+}
+
+var _ Sink = (*netSink)(nil)
+
+func newNetSink(cfg netConfig, dial func(string, string, time.Duration) (net.Conn, error)) *netSink {
+	s := &netSink{
+		cfg:   cfg,
+		dial:  dial,
+		queue: make([][]byte, cfg.queueSize),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	s.cond = sync.NewCond(&s.mu)
+	go s.sendLoop()
+	return s
+}
+
+// Write queues a copy of p to be sent. It doesn't wait for p to be sent, and
+// it reports success even if the overflow policy drops an entry; dropped
+// entries are reported by the next call to Sync.
+func (s *netSink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for s.n == len(s.queue) && s.cfg.overflow == netBlock && !s.closed {
+		s.cond.Wait()
+	}
+	if s.closed {
+		return 0, errors.New("network sink is closed")
+	}
+
+	if s.n == len(s.queue) {
+		s.dropped++
+		switch s.cfg.overflow {
+		case netDropNewest:
+			return len(p), nil
+		default: // netDropOldest
+			s.pop()
+		}
+	}
+
+	s.queue[(s.head+s.n)%len(s.queue)] = append([]byte(nil), p...)
+	s.n++
+	s.cond.Broadcast()
+	return len(p), nil
+}
+
+// Sync waits until every queued entry has been sent. It returns an error
+// without waiting if the sink is disconnected, and reports any entries
+// dropped since the previous call.
+func (s *netSink) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for s.pending() > 0 && s.lastErr == nil && !s.closed {
+		s.cond.Wait()
+	}
+
+	var err error
+	if n := s.pending(); n > 0 {
+		err = fmt.Errorf("%d entries still queued for %v %v", n, s.cfg.network, s.cfg.address)
+		if s.lastErr != nil {
+			err = fmt.Errorf("%v: %w", err, s.lastErr)
+		}
+	}
+	if s.dropped > 0 {
+		dropErr := fmt.Errorf("dropped %d entries for %v %v", s.dropped, s.cfg.network, s.cfg.address)
+		if err == nil {
+			err = dropErr
+		} else {
+			err = fmt.Errorf("%v; %v", dropErr, err)
+		}
+		s.dropped = 0
+	}
+	return err
+}
+
+// Close stops accepting entries, makes one last attempt to send the queue
+// if connected, and closes the connection.
+func (s *netSink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.cond.Broadcast()
+	s.mu.Unlock()
+
+	close(s.stop)
+	<-s.done
+	return nil
+}
+
+// pending returns the number of entries that haven't been sent yet.
+//
+// s.mu must be held.
+func (s *netSink) pending() int {
+	if s.inFlight {
+		return s.n + 1
+	}
+	return s.n
+}
+
+// pop removes and returns the oldest entry in the queue.
+//
+// s.mu must be held.
+func (s *netSink) pop() []byte {
+	msg := s.queue[s.head]
+	s.queue[s.head] = nil
+	s.head = (s.head + 1) % len(s.queue)
+	s.n--
+	return msg
+}
+
+// next blocks until there's an entry to send and hands it to the send loop,
+// or returns nil once the sink is closed and there's nothing left to send.
+func (s *netSink) next() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for s.n == 0 && !s.closed {
+		s.cond.Wait()
+	}
+	if s.n == 0 {
+		return nil
+	}
+	s.inFlight = true
+	msg := s.pop()
+	s.cond.Broadcast() // there's room in the queue now
+	return msg
+}
+
+// sent records the outcome of an attempt to send the in-flight entry.
+func (s *netSink) sent(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastErr = err
+	if err == nil {
+		s.inFlight = false
+	}
+	s.cond.Broadcast()
+}
+
+func (s *netSink) stopped() bool {
+	select {
+	case <-s.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *netSink) sendLoop() {
+	defer close(s.done)
+
+	var (
+		conn    net.Conn
+		msg     []byte // in-flight entry
+		backoff = s.cfg.minBackoff
+	)
+	defer func() {
+		if conn != nil {
+			_ = conn.Close()
+		}
+	}()
+
+	// retry waits before the next attempt, doubling the backoff. It returns
+	// false if the sink was closed in the meantime.
+	retry := func() bool {
+		if !s.sleep(backoff) {
+			s.discard()
+			return false
+		}
+		backoff *= 2
+		if backoff > s.cfg.maxBackoff {
+			backoff = s.cfg.maxBackoff
+		}
+		return true
+	}
+
+	for {
+		if msg == nil {
+			if msg = s.next(); msg == nil {
+				return
+			}
+		}
+
+		if conn == nil {
+			c, err := s.dial(s.cfg.network, s.cfg.address, s.cfg.timeout)
+			if err != nil {
+				s.sent(err)
+				if !retry() {
+					return
+				}
+				continue
+			}
+			conn = c
+		}
+
+		_ = conn.SetWriteDeadline(time.Now().Add(s.cfg.timeout))
+		if _, err := conn.Write(msg); err != nil {
+			// Bytes written before the error went to this connection, which
+			// is about to be closed, so the next one gets the whole entry
+			// rather than a fragment.
+			_ = conn.Close()
+			conn = nil
+			s.sent(err)
+			// A peer that accepts connections and then drops them would
+			// otherwise be redialed in a tight loop.
+			if !retry() {
+				return
+			}
+			continue
+		}
+		s.sent(nil)
+		msg = nil
+		backoff = s.cfg.minBackoff
+	}
+}
+
+// sleep waits for d, returning false if the sink is closed in the meantime.
+func (s *netSink) sleep(d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return true
+	case <-s.stop:
+		return false
+	}
+}
+
+// discard drops everything left in the queue after the sink is closed
+// without a working connection.
+func (s *netSink) discard() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for s.n > 0 {
+		s.pop()
+	}
+	s.inFlight = false
+	s.cond.Broadcast()
+}
@@ -21,8 +21,10 @@
 package zap
 
 import (
+	"context"
 	"fmt"
 
+	"go.uber.org/zap/internal/stacktrace"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -38,6 +40,23 @@ func (f optionFunc) apply(log *Logger) {
 	f(log)
 }
 
+// WithContextExtractor registers functions that turn a context.Context into
+// fields. Loggers bound to a context with Logger.Ctx or SugaredLogger.Ctx
+// call them each time they write an entry, and add the resulting fields
+// before those passed at the log site. This is useful to attach request IDs,
+// tenant IDs, and similar values stored in a context without calling With in
+// every request handler. Repeated use of WithContextExtractor is additive.
+//
+// Extractors are called with a non-nil context and must be safe for
+// concurrent use.
+func WithContextExtractor(fns ...func(context.Context) []Field) Option {
+	return optionFunc(func(log *Logger) {
+		// Don't share the backing array with the parent logger.
+		n := len(log.ctxExtractors)
+		log.ctxExtractors = append(log.ctxExtractors[:n:n], fns...)
+	})
+}
+
 // WrapCore wraps or replaces the Logger's underlying zapcore.Core.
 func WrapCore(f func(zapcore.Core) zapcore.Core) Option {
 	return optionFunc(func(log *Logger) {
@@ -112,10 +131,69 @@ func AddCallerSkip(skip int) Option {
 }
 
 // AddStacktrace configures the Logger to record a stack trace for all messages at
-// or above a given level.
-func AddStacktrace(lvl zapcore.LevelEnabler) Option {
+// or above a given level. StacktraceOptions trim the frames it records:
+//
+//	logger = logger.WithOptions(zap.AddStacktrace(
+//		zap.ErrorLevel,
+//		zap.StacktraceSkipPackages("runtime.", "example.com/app/middleware"),
+//		zap.StacktraceCollapsePackages("github.com/gin-gonic/gin"),
+//		zap.StacktraceMaxDepth(20),
+//	))
+func AddStacktrace(lvl zapcore.LevelEnabler, opts ...StacktraceOption) Option {
+	var filter *stacktrace.Filter
+	if len(opts) > 0 {
+		filter = &stacktrace.Filter{}
+		for _, opt := range opts {
+			opt.apply(filter)
+		}
+	}
 	return optionFunc(func(log *Logger) {
 		log.addStack = lvl
+		log.stackFilter = filter
+	})
+}
+
+// StacktraceOption configures the stack traces recorded by AddStacktrace.
+//
+// Frames are matched by the package of their function. A package prefix
+// matches a package and its subpackages, so "go.uber.org/zap" matches both
+// go.uber.org/zap.(*Logger).Error and go.uber.org/zap/zapcore.(*ioCore).Write.
+// Prefixes ending with a period or a slash are matched as plain string
+// prefixes, so "runtime." matches runtime.gopanic but not
+// runtime/debug.Stack.
+type StacktraceOption interface {
+	apply(*stacktrace.Filter)
+}
+
+type stacktraceOptionFunc func(*stacktrace.Filter)
+
+func (f stacktraceOptionFunc) apply(filter *stacktrace.Filter) {
+	f(filter)
+}
+
+// StacktraceSkipPackages drops frames in packages matching any of the given
+// prefixes from stack traces.
+func StacktraceSkipPackages(prefixes ...string) StacktraceOption {
+	return stacktraceOptionFunc(func(filter *stacktrace.Filter) {
+		filter.Skip = append(filter.Skip, prefixes...)
+	})
+}
+
+// StacktraceCollapsePackages collapses consecutive frames in packages
+// matching the same one of the given prefixes into the first of them, so
+// that a trace through an HTTP framework's middleware chain shows where the
+// framework called the application without listing every layer.
+func StacktraceCollapsePackages(prefixes ...string) StacktraceOption {
+	return stacktraceOptionFunc(func(filter *stacktrace.Filter) {
+		filter.Collapse = append(filter.Collapse, prefixes...)
+	})
+}
+
+// StacktraceMaxDepth limits stack traces to the first n frames that are kept
+// after skipping and collapsing frames. Zero or less means no limit.
+func StacktraceMaxDepth(n int) StacktraceOption {
+	return stacktraceOptionFunc(func(filter *stacktrace.Filter) {
+		filter.MaxDepth = n
 	})
 }
 
@@ -184,3 +262,17 @@ func WithClock(clock zapcore.Clock) Option {
 		log.clock = clock
 	})
 }
+
+// OnClose registers a function to be called when the Logger, or any Logger
+// derived from it, is closed with Logger.Close. Use it to tie the lifetime of
+// resources created alongside the Logger to the Logger itself; for example, to
+// stop a zapcore.BufferedWriteSyncer:
+//
+//	ws := &zapcore.BufferedWriteSyncer{WS: os.Stderr}
+//	logger := zap.New(zapcore.NewCore(enc, ws, lvl), zap.OnClose(ws.Stop))
+//	defer logger.Close()
+func OnClose(fn func() error) Option {
+	return optionFunc(func(log *Logger) {
+		log.onClose(fn)
+	})
+}
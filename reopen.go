@@ -0,0 +1,221 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+// reopener is implemented by sinks that write to a path on the local
+// filesystem and can switch to a fresh file at that path.
+type reopener interface {
+	Reopen() error
+}
+
+// ReopenSinks closes and reopens every file opened by Open (and therefore
+// by Config.Build) that hasn't since been closed. Use it after an external
+// tool like logrotate has moved the files aside, so that logs go to new
+// files at the original paths instead of following the old files.
+//
+// Each file is swapped atomically: entries written concurrently with
+// ReopenSinks go either to the old file or to the new one, in full.
+//
+// Standard output and standard error aren't affected.
+func ReopenSinks() error {
+	return _sinkRegistry.reopenSinks()
+}
+
+// ReopenSinksOnSignal starts a goroutine that calls ReopenSinks whenever the
+// process receives one of the given signals, or SIGHUP if none are given.
+// Errors are written to the logger's ErrorOutput.
+//
+// The signal handler is installed when the option is applied, and removed
+// when the Logger is closed with Logger.Close, so apply it once, typically
+// when building the root logger:
+//
+//	logger, err := cfg.Build(zap.ReopenSinksOnSignal())
+//	defer logger.Close()
+func ReopenSinksOnSignal(sigs ...os.Signal) Option {
+	return optionFunc(func(log *Logger) {
+		stop := _sinkRegistry.reopenOnSignal(log.errorOutput, sigs...)
+		log.onClose(func() error {
+			stop()
+			return nil
+		})
+	})
+}
+
+// track registers a sink to be reopened by reopenSinks.
+func (sr *sinkRegistry) track(r reopener) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	sr.reopeners[r] = struct{}{}
+}
+
+// untrack unregisters a sink previously passed to track.
+func (sr *sinkRegistry) untrack(r reopener) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	delete(sr.reopeners, r)
+}
+
+func (sr *sinkRegistry) reopenSinks() error {
+	sr.mu.Lock()
+	rs := make([]reopener, 0, len(sr.reopeners))
+	for r := range sr.reopeners {
+		rs = append(rs, r)
+	}
+	sr.mu.Unlock()
+
+	var err error
+	for _, r := range rs {
+		err = multierr.Append(err, r.Reopen())
+	}
+	return err
+}
+
+// reopenOnSignal calls reopenSinks whenever one of sigs (or SIGHUP) is
+// received, until the returned function is called.
+func (sr *sinkRegistry) reopenOnSignal(errOut zapcore.WriteSyncer, sigs ...os.Signal) (stop func()) {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{syscall.SIGHUP}
+	}
+
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	signal.Notify(ch, sigs...)
+
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case sig := <-ch:
+				if err := sr.reopenSinks(); err != nil {
+					_, _ = fmt.Fprintf(errOut, "%v failed to reopen sinks on %v: %v\n", time.Now().UTC(), sig, err)
+					_ = errOut.Sync()
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(ch)
+		close(done)
+		<-stopped
+	}
+}
+
+// reopenableFile is a file Sink that can be reopened at the same path.
+type reopenableFile struct {
+	sr   *sinkRegistry
+	path string
+	flag int
+	perm os.FileMode
+
+	mu     sync.Mutex
+	file   *os.File
+	closed bool
+}
+
+var (
+	_ Sink     = (*reopenableFile)(nil)
+	_ reopener = (*reopenableFile)(nil)
+)
+
+func (sr *sinkRegistry) openReopenableFile(path string, flag int, perm os.FileMode) (*reopenableFile, error) {
+	f, err := sr.openFile(path, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	rf := &reopenableFile{
+		sr:   sr,
+		path: path,
+		flag: flag,
+		perm: perm,
+		file: f,
+	}
+	sr.track(rf)
+	return rf, nil
+}
+
+func (f *reopenableFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Write(p)
+}
+
+func (f *reopenableFile) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Sync()
+}
+
+func (f *reopenableFile) Close() error {
+	f.sr.untrack(f)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.closed = true
+	return f.file.Close()
+}
+
+// Reopen opens a new file at the original path and swaps it in for the old
+// one, which is synced and closed. If the new file can't be opened, writes
+// continue to go to the old one. Reopening a closed file fails, since
+// ReopenSinks may race with Close.
+func (f *reopenableFile) Reopen() error {
+	// Open the new file before taking the lock so that writers aren't
+	// blocked on the filesystem.
+	nf, err := f.sr.openFile(f.path, f.flag, f.perm)
+	if err != nil {
+		return fmt.Errorf("reopen %q: %w", f.path, err)
+	}
+
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return multierr.Append(
+			fmt.Errorf("reopen %q: file is closed", f.path),
+			nf.Close(),
+		)
+	}
+	old := f.file
+	f.file = nf
+	f.mu.Unlock()
+
+	return multierr.Append(old.Sync(), old.Close())
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build !windows
+
+package zap
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap/internal/ztest"
+)
+
+func TestReopenSinksOnSignal(t *testing.T) {
+	sr := stubSinkRegistry(t)
+	path := filepath.Join(t.TempDir(), "app.log")
+	sink, closeSink, err := Open(path)
+	require.NoError(t, err, "Failed to open sink.")
+	defer closeSink()
+
+	errOut := &ztest.Buffer{}
+	stop := sr.reopenOnSignal(errOut, syscall.SIGUSR1)
+	defer stop()
+
+	require.NoError(t, os.Rename(path, path+".1"), "Failed to move file aside.")
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1), "Failed to send signal.")
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, time.Second, time.Millisecond, "Expected the file to be reopened.")
+
+	_, err = sink.Write([]byte("after\n"))
+	require.NoError(t, err, "Failed to write.")
+	assert.Equal(t, "after\n", readFile(t, path), "Unexpected contents in reopened file.")
+	assert.Empty(t, errOut.String(), "Unexpected error output.")
+}
+
+func TestReopenSinksOnSignalStopsOnClose(t *testing.T) {
+	stubSinkRegistry(t)
+
+	logger := NewNop().WithOptions(ReopenSinksOnSignal(syscall.SIGUSR2))
+	// The leak check in TestMain fails if the signal goroutine outlives the
+	// logger.
+	assert.NoError(t, logger.Close(), "Unexpected error closing logger.")
+}
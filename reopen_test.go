@@ -0,0 +1,179 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReopenSinks(t *testing.T) {
+	stubSinkRegistry(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	sink, closeSink, err := Open(path, "stderr")
+	require.NoError(t, err, "Failed to open sinks.")
+	defer closeSink()
+
+	_, err = sink.Write([]byte("before\n"))
+	require.NoError(t, err, "Failed to write.")
+
+	// Simulate logrotate's "create" mode.
+	rotated := filepath.Join(dir, "app.log.1")
+	require.NoError(t, os.Rename(path, rotated), "Failed to move file aside.")
+
+	require.NoError(t, ReopenSinks(), "Failed to reopen sinks.")
+	_, err = sink.Write([]byte("after\n"))
+	require.NoError(t, err, "Failed to write.")
+
+	assert.Equal(t, "before\n", readFile(t, rotated), "Unexpected contents in rotated file.")
+	assert.Equal(t, "after\n", readFile(t, path), "Unexpected contents in reopened file.")
+}
+
+func TestReopenSinksIgnoresClosedSinks(t *testing.T) {
+	sr := stubSinkRegistry(t)
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	_, closeSink, err := Open(path, "rotate://"+filepath.ToSlash(path)+".r")
+	require.NoError(t, err, "Failed to open sinks.")
+	assert.Len(t, sr.reopeners, 2, "Expected both files to be tracked.")
+
+	closeSink()
+	assert.Empty(t, sr.reopeners, "Expected closed files to be untracked.")
+	assert.NoError(t, ReopenSinks(), "Reopening with no open files should succeed.")
+}
+
+func TestReopenClosedFile(t *testing.T) {
+	sr := stubSinkRegistry(t)
+	var opened []*os.File
+	sr.openFile = func(name string, flag int, perm os.FileMode) (*os.File, error) {
+		f, err := os.OpenFile(name, flag, perm)
+		opened = append(opened, f)
+		return f, err
+	}
+
+	rf, err := sr.openReopenableFile(filepath.Join(t.TempDir(), "app.log"), os.O_WRONLY|os.O_CREATE, 0o666)
+	require.NoError(t, err, "Failed to open file.")
+
+	// ReopenSinks may have listed the file before it was closed.
+	require.NoError(t, rf.Close(), "Failed to close file.")
+	assert.ErrorContains(t, rf.Reopen(), "file is closed", "Expected an error reopening a closed file.")
+
+	require.Len(t, opened, 2, "Expected Reopen to open a new file.")
+	assert.ErrorIs(t, opened[1].Close(), os.ErrClosed, "Expected the new file to be closed.")
+	_, err = rf.Write([]byte("foo"))
+	assert.Error(t, err, "Expected writes to a closed file to fail.")
+}
+
+func TestReopenSinksFailure(t *testing.T) {
+	sr := stubSinkRegistry(t)
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	sink, closeSink, err := Open(path)
+	require.NoError(t, err, "Failed to open sink.")
+	defer closeSink()
+
+	sr.openFile = func(string, int, os.FileMode) (*os.File, error) {
+		return nil, assert.AnError
+	}
+	err = ReopenSinks()
+	assert.ErrorIs(t, err, assert.AnError, "Expected error from reopening.")
+	assert.ErrorContains(t, err, path, "Expected error to name the path.")
+
+	_, err = sink.Write([]byte("still here\n"))
+	require.NoError(t, err, "Expected writes to continue to the old file.")
+	assert.Equal(t, "still here\n", readFile(t, path), "Unexpected file contents.")
+}
+
+func TestReopenSinksRotatingFile(t *testing.T) {
+	stubRotateClock(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	sink := openRotate(t, path, "maxSize=1MB")
+	defer sink.Close()
+
+	_, err := sink.Write([]byte("before\n"))
+	require.NoError(t, err, "Failed to write.")
+	require.NoError(t, os.Rename(path, path+".1"), "Failed to move file aside.")
+
+	require.NoError(t, ReopenSinks(), "Failed to reopen sinks.")
+	_, err = sink.Write([]byte("after\n"))
+	require.NoError(t, err, "Failed to write.")
+
+	assert.Equal(t, "before\n", readFile(t, path+".1"), "Unexpected contents in moved file.")
+	assert.Equal(t, "after\n", readFile(t, path), "Expected size to restart with the new file.")
+}
+
+func TestReopenSinksConcurrentWrites(t *testing.T) {
+	stubSinkRegistry(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	sink, closeSink, err := Open(path)
+	require.NoError(t, err, "Failed to open sink.")
+
+	const (
+		writers = 4
+		lines   = 200
+	)
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < lines; j++ {
+				_, err := fmt.Fprintf(sink, "writer %d line %d\n", i, j)
+				assert.NoError(t, err, "Failed to write.")
+			}
+		}(i)
+	}
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, os.Rename(path, fmt.Sprintf("%s.%d", path, i)), "Failed to move file aside.")
+		require.NoError(t, ReopenSinks(), "Failed to reopen sinks.")
+	}
+	wg.Wait()
+	closeSink()
+
+	var got []string
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, e := range entries {
+		for _, line := range strings.Split(readFile(t, filepath.Join(dir, e.Name())), "\n") {
+			if line != "" {
+				got = append(got, line)
+			}
+		}
+	}
+	require.Len(t, got, writers*lines, "Expected no lost lines.")
+	for _, line := range got {
+		assert.Regexp(t, `^writer \d line \d+$`, line, "Expected no interleaved lines.")
+	}
+}
@@ -0,0 +1,517 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+const (
+	schemeRotate = "rotate"
+
+	// _rotateTimeFormat is the timestamp format used in the names of
+	// rotated backups. It sorts lexically and avoids characters that are
+	// invalid in Windows file names.
+	_rotateTimeFormat = "2006-01-02T15-04-05.000"
+
+	_compressSuffix = ".gz"
+)
+
+// rotateConfig holds the options parsed from a "rotate" URL.
+type rotateConfig struct {
+	path       string
+	maxSize    int64         // bytes; zero disables size-based rotation
+	interval   time.Duration // zero disables time-based rotation
+	maxBackups int           // zero keeps all backups
+	maxAge     time.Duration // zero keeps backups forever
+	compress   bool
+	localTime  bool
+}
+
+// newRotateSinkFromURL builds a rotating file sink from a URL of the form
+//
+//	rotate:///var/log/app.log?maxSize=100MB&interval=24h&maxBackups=7&maxAge=168h&compress=true
+//
+// See Open for the list of supported parameters.
+func (sr *sinkRegistry) newRotateSinkFromURL(u *url.URL) (Sink, error) {
+	if u.User != nil {
+		return nil, fmt.Errorf("user and password not allowed with rotate URLs: got %v", u)
+	}
+	if u.Fragment != "" {
+		return nil, fmt.Errorf("fragments not allowed with rotate URLs: got %v", u)
+	}
+	if u.Port() != "" {
+		return nil, fmt.Errorf("ports not allowed with rotate URLs: got %v", u)
+	}
+	if hn := u.Hostname(); hn != "" && hn != "localhost" {
+		return nil, fmt.Errorf("rotate URLs must leave host empty or use localhost: got %v", u)
+	}
+	if u.Path == "" {
+		return nil, fmt.Errorf("rotate URLs must specify a path: got %v", u)
+	}
+
+	cfg, err := parseRotateQuery(u.Query())
+	if err != nil {
+		return nil, fmt.Errorf("invalid rotate URL %v: %w", u, err)
+	}
+	cfg.path = u.Path
+	return sr.newRotatingFile(cfg)
+}
+
+func parseRotateQuery(q url.Values) (rotateConfig, error) {
+	var (
+		cfg rotateConfig
+		err error
+	)
+	for key, vals := range q {
+		if len(vals) != 1 {
+			return cfg, fmt.Errorf("parameter %q must be specified exactly once", key)
+		}
+		val := vals[0]
+		switch key {
+		case "maxSize":
+			cfg.maxSize, err = parseByteSize(val)
+		case "interval":
+			cfg.interval, err = parsePositiveDuration(val)
+		case "maxAge":
+			cfg.maxAge, err = parsePositiveDuration(val)
+		case "maxBackups":
+			cfg.maxBackups, err = strconv.Atoi(val)
+			if err == nil && cfg.maxBackups < 0 {
+				err = errors.New("must not be negative")
+			}
+		case "compress":
+			cfg.compress, err = strconv.ParseBool(val)
+		case "localTime":
+			cfg.localTime, err = strconv.ParseBool(val)
+		default:
+			return cfg, fmt.Errorf("unknown parameter %q", key)
+		}
+		if err != nil {
+			return cfg, fmt.Errorf("can't parse %q parameter %q: %v", key, val, err)
+		}
+	}
+	return cfg, nil
+}
+
+// parseByteSize parses sizes like "512", "64KB", "100MB" or "1GB". Units are
+// powers of 1024 and are case-insensitive.
+func parseByteSize(s string) (int64, error) {
+	units := []struct {
+		suffix string
+		scale  int64
+	}{
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	}
+
+	num, scale := strings.ToUpper(strings.TrimSpace(s)), int64(1)
+	for _, u := range units {
+		if strings.HasSuffix(num, u.suffix) {
+			num, scale = strings.TrimSpace(strings.TrimSuffix(num, u.suffix)), u.scale
+			break
+		}
+	}
+	n, err := strconv.ParseInt(num, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if n <= 0 {
+		return 0, errors.New("must be positive")
+	}
+	if n > math.MaxInt64/scale {
+		return 0, errors.New("too large")
+	}
+	return n * scale, nil
+}
+
+func parsePositiveDuration(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, errors.New("must be positive")
+	}
+	return d, nil
+}
+
+// rotatingFile is a Sink that writes to a file and rotates it once it
+// reaches a maximum size, once a wall-clock interval elapses, or both.
+//
+// Rotated files are renamed to include the time of rotation, for example
+// "app-2006-01-02T15-04-05.000.log". Compression and removal of old backups
+// happen in the background so that rotation doesn't stall writers.
+type rotatingFile struct {
+	cfg rotateConfig
+	sr  *sinkRegistry
+
+	mu         sync.Mutex
+	file       *os.File // nil if closed, or if opening a new file failed
+	closed     bool
+	size       int64
+	rotateAt   time.Time // zero if time-based rotation is disabled
+	lastBackup time.Time // used to keep backup names unique
+
+	millMu sync.Mutex     // serializes compression and cleanup
+	millWG sync.WaitGroup // tracks background compression and cleanup
+}
+
+var (
+	_ Sink     = (*rotatingFile)(nil)
+	_ reopener = (*rotatingFile)(nil)
+)
+
+func (sr *sinkRegistry) newRotatingFile(cfg rotateConfig) (Sink, error) {
+	r := &rotatingFile{cfg: cfg, sr: sr}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.openExistingOrNew(); err != nil {
+		return nil, err
+	}
+	r.scheduleRotation()
+	sr.track(r)
+	return r, nil
+}
+
+// Write writes p to the current file, rotating it first if necessary.
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return 0, errors.New("rotating file is closed")
+	}
+	if r.file == nil {
+		// A previous rotation couldn't open a new file. Try again.
+		if err := r.openExistingOrNew(); err != nil {
+			return 0, err
+		}
+	}
+
+	var rotateErr error
+	if r.shouldRotate(int64(len(p))) {
+		rotateErr = r.rotate()
+		if r.file == nil {
+			return 0, rotateErr
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, multierr.Append(rotateErr, err)
+}
+
+// Sync commits the current file to stable storage.
+func (r *rotatingFile) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Sync()
+}
+
+// Close closes the current file and waits for any background compression
+// or cleanup to finish.
+func (r *rotatingFile) Close() error {
+	r.sr.untrack(r)
+
+	r.mu.Lock()
+	var err error
+	if r.file != nil {
+		err = r.file.Close()
+		r.file = nil
+	}
+	r.closed = true
+	r.mu.Unlock()
+
+	r.millWG.Wait()
+	return err
+}
+
+// Reopen closes the current file and opens the configured path again,
+// without rotating. This lets an external tool take over rotation.
+func (r *rotatingFile) Reopen() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+
+	old := r.file
+	if err := r.openExistingOrNew(); err != nil {
+		return fmt.Errorf("reopen %q: %w", r.cfg.path, err)
+	}
+	if old == nil {
+		return nil
+	}
+	return multierr.Append(old.Sync(), old.Close())
+}
+
+// shouldRotate reports whether a write of n bytes requires a rotation. A
+// single write larger than maxSize is written to a fresh file rather than
+// being rejected.
+//
+// r.mu must be held.
+func (r *rotatingFile) shouldRotate(n int64) bool {
+	if r.cfg.maxSize > 0 && r.size > 0 && r.size+n > r.cfg.maxSize {
+		return true
+	}
+	return !r.rotateAt.IsZero() && !r.now().Before(r.rotateAt)
+}
+
+// openExistingOrNew opens the configured path for appending, creating it if
+// necessary.
+//
+// r.mu must be held.
+func (r *rotatingFile) openExistingOrNew() error {
+	f, err := r.sr.openFile(r.cfg.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o666)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	r.file = f
+	r.size = info.Size()
+	return nil
+}
+
+// rotate closes the current file, moves it aside, and opens a new one.
+//
+// If the file can't be moved aside, rotate reopens it so that writes can
+// continue, and the rotation is retried on a later write; the next interval
+// rotation isn't scheduled until one succeeds. If no file can be opened,
+// r.file is left nil and Write tries to open one again.
+//
+// r.mu must be held.
+func (r *rotatingFile) rotate() error {
+	err := r.file.Close()
+	r.file = nil
+
+	if rerr := os.Rename(r.cfg.path, r.backupName()); rerr != nil && !errors.Is(rerr, os.ErrNotExist) {
+		err = multierr.Append(err, fmt.Errorf("can't rename log file: %w", rerr))
+	} else {
+		r.scheduleRotation()
+	}
+	if oerr := r.openExistingOrNew(); oerr != nil {
+		return multierr.Append(err, oerr)
+	}
+	if err != nil {
+		return err
+	}
+
+	r.millWG.Add(1)
+	go func() {
+		defer r.millWG.Done()
+		r.mill()
+	}()
+	return nil
+}
+
+// scheduleRotation records the next wall-clock boundary at which the file
+// should be rotated. Boundaries are multiples of the interval in the
+// configured location's wall-clock time, so with localTime a daily interval
+// rotates at local midnight.
+//
+// r.mu must be held.
+func (r *rotatingFile) scheduleRotation() {
+	if r.cfg.interval <= 0 {
+		return
+	}
+
+	// Truncate the wall-clock reading, then interpret the boundary in the
+	// original location. time.Date resolves daylight saving transitions.
+	now := r.now()
+	wall := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second(), now.Nanosecond(), time.UTC)
+	next := wall.Truncate(r.cfg.interval).Add(r.cfg.interval)
+	r.rotateAt = time.Date(next.Year(), next.Month(), next.Day(), next.Hour(), next.Minute(), next.Second(), next.Nanosecond(), now.Location())
+}
+
+// backupName returns a unique name for the next backup.
+//
+// r.mu must be held.
+func (r *rotatingFile) backupName() string {
+	t := r.now()
+	if !t.After(r.lastBackup) {
+		// Two rotations within the resolution of the timestamp format would
+		// otherwise overwrite each other.
+		t = r.lastBackup.Add(time.Millisecond)
+	}
+	r.lastBackup = t
+
+	dir, prefix, ext := r.nameParts()
+	return filepath.Join(dir, prefix+t.Format(_rotateTimeFormat)+ext)
+}
+
+func (r *rotatingFile) now() time.Time {
+	return r.sr.clock.Now().In(r.location())
+}
+
+// location returns the location of timestamps in backup names and of
+// rotation boundaries.
+func (r *rotatingFile) location() *time.Location {
+	if r.cfg.localTime {
+		return r.sr.local
+	}
+	return time.UTC
+}
+
+// nameParts splits the configured path into the pieces used to build and
+// recognize backup names: "/var/log/app.log" becomes "/var/log", "app-" and
+// ".log".
+func (r *rotatingFile) nameParts() (dir, prefix, ext string) {
+	dir = filepath.Dir(r.cfg.path)
+	base := filepath.Base(r.cfg.path)
+	ext = filepath.Ext(base)
+	prefix = strings.TrimSuffix(base, ext) + "-"
+	return dir, prefix, ext
+}
+
+type rotatedBackup struct {
+	path       string
+	rotatedAt  time.Time
+	compressed bool
+}
+
+// backups lists existing backups of the current file, newest first.
+func (r *rotatingFile) backups() ([]rotatedBackup, error) {
+	dir, prefix, ext := r.nameParts()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []rotatedBackup
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		compressed := strings.HasSuffix(name, ext+_compressSuffix)
+		stamp := strings.TrimSuffix(name, _compressSuffix)
+		if !strings.HasPrefix(stamp, prefix) || !strings.HasSuffix(stamp, ext) {
+			continue
+		}
+		stamp = strings.TrimSuffix(strings.TrimPrefix(stamp, prefix), ext)
+		t, err := time.ParseInLocation(_rotateTimeFormat, stamp, r.location())
+		if err != nil {
+			continue // not one of ours
+		}
+		backups = append(backups, rotatedBackup{
+			path:       filepath.Join(dir, name),
+			rotatedAt:  t,
+			compressed: compressed,
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].rotatedAt.After(backups[j].rotatedAt)
+	})
+	return backups, nil
+}
+
+// mill removes backups beyond maxBackups or older than maxAge, and compresses
+// the remaining ones if requested. Errors are ignored: the next rotation will
+// try again.
+func (r *rotatingFile) mill() {
+	r.millMu.Lock()
+	defer r.millMu.Unlock()
+
+	backups, err := r.backups()
+	if err != nil {
+		return
+	}
+
+	cutoff := time.Time{}
+	if r.cfg.maxAge > 0 {
+		cutoff = r.sr.clock.Now().Add(-r.cfg.maxAge)
+	}
+
+	for i, b := range backups {
+		expired := (r.cfg.maxBackups > 0 && i >= r.cfg.maxBackups) ||
+			(!cutoff.IsZero() && b.rotatedAt.Before(cutoff))
+		switch {
+		case expired:
+			_ = os.Remove(b.path)
+		case r.cfg.compress && !b.compressed:
+			_ = compressFile(b.path)
+		}
+	}
+}
+
+// compressFile gzips src into src+".gz" and removes src on success.
+func compressFile(src string) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	dst := src + _compressSuffix
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o666)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = out.Close()
+			_ = os.Remove(dst)
+		}
+	}()
+
+	gz := gzip.NewWriter(out)
+	if _, err = io.Copy(gz, in); err != nil {
+		return err
+	}
+	if err = gz.Close(); err != nil {
+		return err
+	}
+	if err = out.Close(); err != nil {
+		return err
+	}
+	_ = in.Close()
+	return os.Remove(src)
+}
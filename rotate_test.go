@@ -0,0 +1,380 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap/internal/ztest"
+)
+
+func stubRotateClock(t testing.TB) *ztest.MockClock {
+	clock := ztest.NewMockClock()
+	stubSinkRegistry(t).clock = clock
+	return clock
+}
+
+func openRotate(t testing.TB, path, query string) Sink {
+	u := url.URL{Scheme: schemeRotate, Path: path, RawQuery: query}
+	sink, err := _sinkRegistry.newSink(u.String())
+	require.NoError(t, err, "Failed to open rotating sink.")
+	return sink
+}
+
+// listDir returns the sorted names of the files in dir.
+func listDir(t testing.TB, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err, "Failed to read directory.")
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func readFile(t testing.TB, path string) string {
+	b, err := os.ReadFile(path)
+	require.NoError(t, err, "Failed to read %q.", path)
+	return string(b)
+}
+
+func TestRotateSinkURLErrors(t *testing.T) {
+	tests := []struct {
+		url string
+		err string
+	}{
+		{"rotate://user:pass@/tmp/app.log", "user and password not allowed"},
+		{"rotate:///tmp/app.log#frag", "fragments not allowed"},
+		{"rotate://localhost:8080/tmp/app.log", "ports not allowed"},
+		{"rotate://example.com/tmp/app.log", "must leave host empty"},
+		{"rotate://", "must specify a path"},
+		{"rotate:///tmp/app.log?foo=bar", `unknown parameter "foo"`},
+		{"rotate:///tmp/app.log?maxSize=1&maxSize=2", "exactly once"},
+		{"rotate:///tmp/app.log?maxSize=lots", `"maxSize"`},
+		{"rotate:///tmp/app.log?maxSize=0MB", "must be positive"},
+		{"rotate:///tmp/app.log?maxSize=9999999999999GB", "too large"},
+		{"rotate:///tmp/app.log?interval=-1h", "must be positive"},
+		{"rotate:///tmp/app.log?maxAge=forever", `"maxAge"`},
+		{"rotate:///tmp/app.log?maxBackups=-1", "must not be negative"},
+		{"rotate:///tmp/app.log?compress=maybe", `"compress"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			_, err := newSinkRegistry().newSink(tt.url)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		give string
+		want int64
+	}{
+		{"42", 42},
+		{"42B", 42},
+		{"2kb", 2 << 10},
+		{"100MB", 100 << 20},
+		{"1 GB", 1 << 30},
+	}
+
+	for _, tt := range tests {
+		got, err := parseByteSize(tt.give)
+		if assert.NoError(t, err, "Failed to parse %q.", tt.give) {
+			assert.Equal(t, tt.want, got, "Unexpected size for %q.", tt.give)
+		}
+	}
+}
+
+func TestRotateSinkBySize(t *testing.T) {
+	clock := stubRotateClock(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	sink := openRotate(t, path, "maxSize=10B")
+	for _, msg := range []string{"aaaa\n", "bbbb\n", "cccc\n"} {
+		_, err := sink.Write([]byte(msg))
+		require.NoError(t, err, "Failed to write.")
+		clock.Add(time.Second)
+	}
+	require.NoError(t, sink.Close(), "Failed to close sink.")
+
+	names := listDir(t, dir)
+	require.Len(t, names, 2, "Expected one backup and the active file.")
+	assert.Equal(t, "app.log", names[1], "Unexpected active file name.")
+	assert.True(t, strings.HasPrefix(names[0], "app-"), "Unexpected backup name %q.", names[0])
+	assert.Equal(t, "aaaa\nbbbb\n", readFile(t, filepath.Join(dir, names[0])), "Unexpected backup contents.")
+	assert.Equal(t, "cccc\n", readFile(t, path), "Unexpected active file contents.")
+}
+
+func TestRotateSinkAppendsToExistingFile(t *testing.T) {
+	stubRotateClock(t)
+	path := filepath.Join(t.TempDir(), "app.log")
+	require.NoError(t, os.WriteFile(path, []byte("12345678"), 0o666))
+
+	sink := openRotate(t, path, "maxSize=10")
+	_, err := sink.Write([]byte("abc"))
+	require.NoError(t, err, "Failed to write.")
+	require.NoError(t, sink.Close(), "Failed to close sink.")
+
+	assert.Equal(t, "abc", readFile(t, path), "Existing size should count toward maxSize.")
+}
+
+func TestRotateSinkByInterval(t *testing.T) {
+	clock := stubRotateClock(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	sink := openRotate(t, path, "interval=1h")
+	_, err := sink.Write([]byte("first\n"))
+	require.NoError(t, err, "Failed to write.")
+
+	clock.Add(time.Hour)
+	_, err = sink.Write([]byte("second\n"))
+	require.NoError(t, err, "Failed to write.")
+	require.NoError(t, sink.Close(), "Failed to close sink.")
+
+	names := listDir(t, dir)
+	require.Len(t, names, 2, "Expected one backup and the active file.")
+	assert.Equal(t, "first\n", readFile(t, filepath.Join(dir, names[0])), "Unexpected backup contents.")
+	assert.Equal(t, "second\n", readFile(t, path), "Unexpected active file contents.")
+}
+
+func TestRotateSinkMaxBackupsAndCompress(t *testing.T) {
+	clock := stubRotateClock(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	sink := openRotate(t, path, "maxSize=4&maxBackups=2&compress=true")
+	for _, msg := range []string{"one\n", "two\n", "tri\n", "for\n"} {
+		_, err := sink.Write([]byte(msg))
+		require.NoError(t, err, "Failed to write.")
+		clock.Add(time.Second)
+	}
+	require.NoError(t, sink.Close(), "Failed to close sink.")
+
+	names := listDir(t, dir)
+	require.Len(t, names, 3, "Expected two backups and the active file.")
+	assert.Equal(t, "app.log", names[2], "Unexpected active file name.")
+
+	var contents []string
+	for _, name := range names[:2] {
+		require.True(t, strings.HasSuffix(name, ".log.gz"), "Expected compressed backup, got %q.", name)
+		f, err := os.Open(filepath.Join(dir, name))
+		require.NoError(t, err)
+		gz, err := gzip.NewReader(f)
+		require.NoError(t, err, "Backup isn't valid gzip.")
+		b, err := io.ReadAll(gz)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		contents = append(contents, string(b))
+	}
+	assert.Equal(t, []string{"two\n", "tri\n"}, contents, "Oldest backup should be removed.")
+}
+
+func TestRotateSinkMaxAge(t *testing.T) {
+	clock := stubRotateClock(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	sink := openRotate(t, path, "maxSize=4&maxAge=1h")
+	_, err := sink.Write([]byte("old\n"))
+	require.NoError(t, err)
+	_, err = sink.Write([]byte("mid\n")) // rotates "old"
+	require.NoError(t, err)
+
+	clock.Add(2 * time.Hour)
+	_, err = sink.Write([]byte("new\n")) // rotates "mid", expires "old"
+	require.NoError(t, err)
+	require.NoError(t, sink.Close(), "Failed to close sink.")
+
+	names := listDir(t, dir)
+	require.Len(t, names, 2, "Expected one backup and the active file.")
+	assert.Equal(t, "mid\n", readFile(t, filepath.Join(dir, names[0])), "Unexpected surviving backup.")
+}
+
+func TestRotateSinkFromConfig(t *testing.T) {
+	stubRotateClock(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	cfg := NewProductionConfig()
+	cfg.Sampling = nil
+	cfg.EncoderConfig.TimeKey = ""
+	cfg.OutputPaths = []string{"rotate://" + filepath.ToSlash(path) + "?maxSize=1MB&maxBackups=3"}
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Failed to build logger.")
+	logger.Info("hello")
+	require.NoError(t, logger.Close(), "Failed to close logger.")
+
+	assert.Contains(t, readFile(t, path), `"msg":"hello"`, "Expected message in rotating file.")
+}
+
+func TestRotateSinkWriteAfterClose(t *testing.T) {
+	stubRotateClock(t)
+	sink := openRotate(t, filepath.Join(t.TempDir(), "app.log"), "")
+	require.NoError(t, sink.Close(), "Failed to close sink.")
+
+	_, err := sink.Write([]byte("foo"))
+	assert.ErrorContains(t, err, "closed", "Expected error writing to closed sink.")
+	assert.NoError(t, sink.Sync(), "Sync after close should be a no-op.")
+}
+
+func TestRotateSinkLocalTimeBoundaries(t *testing.T) {
+	clock := stubRotateClock(t)
+	zone := time.FixedZone("UTC+5", 5*60*60)
+	_sinkRegistry.local = zone
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	// Start at 22:30 local time, which is 17:30 UTC.
+	now := clock.Now().In(zone)
+	start := time.Date(now.Year(), now.Month(), now.Day()+1, 22, 30, 0, 0, zone)
+	clock.Add(start.Sub(now))
+
+	sink := openRotate(t, path, "interval=24h&localTime=true")
+	midnight := time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, zone)
+	assert.True(t, midnight.Equal(sink.(*rotatingFile).rotateAt),
+		"Expected rotation at local midnight %v, got %v.", midnight, sink.(*rotatingFile).rotateAt)
+
+	_, err := sink.Write([]byte("before midnight\n"))
+	require.NoError(t, err, "Failed to write.")
+	clock.Add(midnight.Sub(start) - time.Minute)
+	_, err = sink.Write([]byte("still before midnight\n"))
+	require.NoError(t, err, "Failed to write.")
+	clock.Add(time.Minute)
+	_, err = sink.Write([]byte("after midnight\n"))
+	require.NoError(t, err, "Failed to write.")
+	require.NoError(t, sink.Close(), "Failed to close sink.")
+
+	names := listDir(t, dir)
+	require.Len(t, names, 2, "Expected one backup and the active file.")
+	assert.Equal(t, "app-"+midnight.Format(_rotateTimeFormat)+".log", names[0], "Expected a backup named in local time.")
+	assert.Equal(t, "before midnight\nstill before midnight\n", readFile(t, filepath.Join(dir, names[0])),
+		"Unexpected backup contents.")
+	assert.Equal(t, "after midnight\n", readFile(t, path), "Unexpected active file contents.")
+}
+
+func TestRotateSinkRecoversFromFailedRotation(t *testing.T) {
+	clock := stubRotateClock(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	var failOpen bool
+	_sinkRegistry.openFile = func(name string, flag int, perm os.FileMode) (*os.File, error) {
+		if failOpen {
+			return nil, errors.New("fail")
+		}
+		return os.OpenFile(name, flag, perm)
+	}
+
+	sink := openRotate(t, path, "maxSize=10B")
+	_, err := sink.Write([]byte("aaaa\nbbbb\n"))
+	require.NoError(t, err, "Failed to write.")
+
+	failOpen = true
+	clock.Add(time.Second)
+	_, err = sink.Write([]byte("lost\n"))
+	assert.ErrorContains(t, err, "fail", "Expected an error opening the new file.")
+
+	failOpen = false
+	clock.Add(time.Second)
+	_, err = sink.Write([]byte("cccc\n"))
+	require.NoError(t, err, "Expected writes to succeed once files can be opened again.")
+	require.NoError(t, sink.Close(), "Failed to close sink.")
+
+	names := listDir(t, dir)
+	require.Len(t, names, 2, "Expected one backup and the active file.")
+	assert.Equal(t, "aaaa\nbbbb\n", readFile(t, filepath.Join(dir, names[0])), "Unexpected backup contents.")
+	assert.Equal(t, "cccc\n", readFile(t, path), "Unexpected active file contents.")
+}
+
+func TestRotateSinkFailedRenameKeepsWriting(t *testing.T) {
+	clock := stubRotateClock(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	sink := openRotate(t, path, "maxSize=10B")
+	_, err := sink.Write([]byte("aaaa\nbbbb\n"))
+	require.NoError(t, err, "Failed to write.")
+
+	// A directory in the way of the backup makes the rename fail.
+	clock.Add(time.Second)
+	backup := filepath.Join(dir, "app-"+clock.Now().UTC().Format(_rotateTimeFormat)+".log")
+	require.NoError(t, os.Mkdir(backup, 0o777), "Failed to create directory.")
+	require.NoError(t, os.WriteFile(filepath.Join(backup, "f"), nil, 0o666), "Failed to create file.")
+	_, err = sink.Write([]byte("cccc\n"))
+	assert.ErrorContains(t, err, "can't rename log file", "Expected an error renaming the file.")
+
+	clock.Add(time.Second)
+	_, err = sink.Write([]byte("dddd\n"))
+	require.NoError(t, err, "Expected the rotation to be retried.")
+	require.NoError(t, sink.Close(), "Failed to close sink.")
+
+	names := listDir(t, dir)
+	require.Len(t, names, 3, "Expected the directory, one backup, and the active file.")
+	assert.Equal(t, "aaaa\nbbbb\ncccc\n", readFile(t, filepath.Join(dir, names[1])), "Expected the entry to be kept after a failed rename.")
+	assert.Equal(t, "dddd\n", readFile(t, path), "Unexpected active file contents.")
+}
+
+func TestRotateSinkFailedIntervalRotationKeepsDeadline(t *testing.T) {
+	clock := stubRotateClock(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	sink := openRotate(t, path, "interval=1h")
+	_, err := sink.Write([]byte("first\n"))
+	require.NoError(t, err, "Failed to write.")
+
+	// A directory in the way of the backup makes the rename fail.
+	clock.Add(time.Hour)
+	backup := filepath.Join(dir, "app-"+clock.Now().UTC().Format(_rotateTimeFormat)+".log")
+	require.NoError(t, os.Mkdir(backup, 0o777), "Failed to create directory.")
+	require.NoError(t, os.WriteFile(filepath.Join(backup, "f"), nil, 0o666), "Failed to create file.")
+	_, err = sink.Write([]byte("second\n"))
+	assert.ErrorContains(t, err, "can't rename log file", "Expected an error renaming the file.")
+
+	// The rotation is retried on the next write, not an interval later.
+	clock.Add(time.Second)
+	_, err = sink.Write([]byte("third\n"))
+	require.NoError(t, err, "Expected the rotation to be retried.")
+	require.NoError(t, sink.Close(), "Failed to close sink.")
+
+	names := listDir(t, dir)
+	require.Len(t, names, 3, "Expected the directory, one backup, and the active file.")
+	assert.Equal(t, "first\nsecond\n", readFile(t, filepath.Join(dir, names[1])), "Unexpected backup contents.")
+	assert.Equal(t, "third\n", readFile(t, path), "Unexpected active file contents.")
+}
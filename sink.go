@@ -29,6 +29,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"go.uber.org/zap/zapcore"
 )
@@ -59,15 +60,25 @@ type sinkRegistry struct {
 	mu        sync.Mutex
 	factories map[string]func(*url.URL) (Sink, error)          // keyed by scheme
 	openFile  func(string, int, os.FileMode) (*os.File, error) // type matches os.OpenFile
+	clock     zapcore.Clock                                    // used by rotating sinks
+	local     *time.Location                                   // used by rotating sinks with localTime
+	reopeners map[reopener]struct{}                            // open sinks affected by ReopenSinks
 }
 
 func newSinkRegistry() *sinkRegistry {
 	sr := &sinkRegistry{
 		factories: make(map[string]func(*url.URL) (Sink, error)),
 		openFile:  os.OpenFile,
+		clock:     zapcore.DefaultClock,
+		local:     time.Local,
+		reopeners: make(map[reopener]struct{}),
 	}
-	// Infallible operation: the registry is empty, so we can't have a conflict.
+	// Infallible operations: the registry is empty, so we can't have a conflict.
 	_ = sr.RegisterSink(schemeFile, sr.newFileSinkFromURL)
+	_ = sr.RegisterSink(schemeRotate, sr.newRotateSinkFromURL)
+	_ = sr.RegisterSink(schemeSyslog, sr.newSyslogSinkFromURL)
+	_ = sr.RegisterSink(schemeTCP, newNetSinkFromURL)
+	_ = sr.RegisterSink(schemeUDP, newNetSinkFromURL)
 	return sr
 }
 
@@ -121,8 +132,8 @@ func (sr *sinkRegistry) newSink(rawURL string) (Sink, error) {
 //
 // All schemes must be ASCII, valid under section 0.1 of RFC 3986
 // (https://tools.ietf.org/html/rfc3983#section-3.1), and must not already
-// have a factory registered. Zap automatically registers a factory for the
-// "file" scheme.
+// have a factory registered. Zap automatically registers factories for the
+// "file", "rotate", "syslog", "tcp" and "udp" schemes.
 func RegisterSink(scheme string, factory func(*url.URL) (Sink, error)) error {
 	return _sinkRegistry.RegisterSink(scheme, factory)
 }
@@ -155,7 +166,11 @@ func (sr *sinkRegistry) newFileSinkFromPath(path string) (Sink, error) {
 	case "stderr":
 		return nopCloserSink{os.Stderr}, nil
 	}
-	return sr.openFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o666)
+	f, err := sr.openReopenableFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o666)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
 }
 
 func normalizeScheme(s string) (string, error) {
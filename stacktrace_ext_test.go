@@ -32,6 +32,7 @@ import (
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -143,6 +144,90 @@ func TestStacktraceWithCallerSkip(t *testing.T) {
 	})
 }
 
+func TestStacktraceFrames(t *testing.T) {
+	buf := &bytes.Buffer{}
+	encCfg := zap.NewProductionEncoderConfig()
+	encCfg.EncodeStacktrace = zapcore.FramesStacktraceEncoder
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(encCfg), zapcore.AddSync(buf), zapcore.DebugLevel)
+	zap.New(core, zap.AddStacktrace(zap.ErrorLevel)).Error("test log")
+
+	var entry struct {
+		Stacktrace []struct {
+			Function string `json:"function"`
+			File     string `json:"file"`
+			Line     int    `json:"line"`
+		} `json:"stacktrace"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry), "Expected stack trace to be encoded as frames: %s", buf)
+	require.NotEmpty(t, entry.Stacktrace, "Expected at least one frame.")
+	top := entry.Stacktrace[0]
+	assert.Equal(t, "go.uber.org/zap_test.TestStacktraceFrames", top.Function, "Expected the stack to start with the test.")
+	assert.Equal(t, getSelfFilename(t), filepath.Base(top.File), "Unexpected file.")
+	assert.NotZero(t, top.Line, "Expected a line number.")
+}
+
+func TestStacktraceOptions(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []zap.StacktraceOption
+		want []string
+	}{
+		{
+			desc: "no options",
+			want: []string{"go.uber.org/zap_test.TestStacktraceOptions.func1.1", "go.uber.org/zap_test.TestStacktraceOptions.func1", "testing.tRunner"},
+		},
+		{
+			desc: "skip packages",
+			opts: []zap.StacktraceOption{zap.StacktraceSkipPackages("testing.")},
+			want: []string{"go.uber.org/zap_test.TestStacktraceOptions.func1.1", "go.uber.org/zap_test.TestStacktraceOptions.func1"},
+		},
+		{
+			desc: "collapse packages",
+			opts: []zap.StacktraceOption{zap.StacktraceCollapsePackages("go.uber.org/zap_test")},
+			want: []string{"go.uber.org/zap_test.TestStacktraceOptions.func1.1", "testing.tRunner"},
+		},
+		{
+			desc: "max depth",
+			opts: []zap.StacktraceOption{zap.StacktraceMaxDepth(1)},
+			want: []string{"go.uber.org/zap_test.TestStacktraceOptions.func1.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			logger := zap.New(core, zap.AddStacktrace(zap.ErrorLevel, tt.opts...))
+			func() {
+				logger.Error("test log")
+			}()
+
+			require.Equal(t, 1, logs.Len(), "Expected one entry.")
+			assert.Equal(t, tt.want, stackFunctions(logs.All()[0].Stack), "Unexpected stack trace.")
+		})
+	}
+}
+
+func TestStacktraceOptionsReset(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(core, zap.AddStacktrace(zap.ErrorLevel, zap.StacktraceMaxDepth(1)))
+	logger.WithOptions(zap.AddStacktrace(zap.ErrorLevel)).Error("test log")
+
+	require.Equal(t, 1, logs.Len(), "Expected one entry.")
+	assert.Equal(t, []string{"go.uber.org/zap_test.TestStacktraceOptionsReset", "testing.tRunner"},
+		stackFunctions(logs.All()[0].Stack), "Expected AddStacktrace without options to keep every frame.")
+}
+
+// stackFunctions returns the functions in a stack trace.
+func stackFunctions(stack string) []string {
+	var functions []string
+	for _, line := range strings.Split(stack, "\n") {
+		if !strings.HasPrefix(line, "\t") {
+			functions = append(functions, line)
+		}
+	}
+	return functions
+}
+
 // withLogger sets up a logger with a real encoder set up, so that any marshal functions are called.
 // The inbuilt observer does not call Marshal for objects/arrays, which we need for some tests.
 func withLogger(t *testing.T, fn func(logger *zap.Logger, out *bytes.Buffer)) {
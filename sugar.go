@@ -21,6 +21,7 @@
 package zap
 
 import (
+	"context"
 	"fmt"
 
 	"go.uber.org/zap/zapcore"
@@ -71,6 +72,12 @@ func (s *SugaredLogger) Named(name string) *SugaredLogger {
 	return &SugaredLogger{base: s.base.Named(name)}
 }
 
+// Ctx returns a child logger that attaches fields taken from ctx to every
+// entry it writes. See Logger.Ctx and WithContextExtractor for details.
+func (s *SugaredLogger) Ctx(ctx context.Context) *SugaredLogger {
+	return &SugaredLogger{base: s.base.Ctx(ctx)}
+}
+
 // WithOptions clones the current SugaredLogger, applies the supplied Options,
 // and returns the result. It's safe to use concurrently.
 func (s *SugaredLogger) WithOptions(opts ...Option) *SugaredLogger {
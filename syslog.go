@@ -0,0 +1,359 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	schemeSyslog = "syslog"
+
+	_syslogDefaultPort = "514"
+	_syslogDialTimeout = 5 * time.Second
+)
+
+// syslogFormat selects the syslog message format.
+type syslogFormat int
+
+const (
+	syslogRFC5424 syslogFormat = iota
+	syslogRFC3164
+)
+
+var _syslogFacilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// syslogSeverity maps a zap level to a syslog severity as defined in
+// section 6.2.1 of RFC 5424.
+func syslogSeverity(lvl zapcore.Level) int {
+	switch lvl {
+	case zapcore.DebugLevel:
+		return 7 // debug
+	case zapcore.InfoLevel:
+		return 6 // informational
+	case zapcore.WarnLevel:
+		return 4 // warning
+	case zapcore.ErrorLevel:
+		return 3 // error
+	case zapcore.DPanicLevel:
+		return 2 // critical
+	case zapcore.PanicLevel:
+		return 1 // alert
+	case zapcore.FatalLevel:
+		return 0 // emergency
+	default:
+		if lvl < zapcore.DebugLevel {
+			return 7
+		}
+		return 0
+	}
+}
+
+// syslogConfig holds the options parsed from a "syslog" URL.
+type syslogConfig struct {
+	network  string
+	address  string
+	format   syslogFormat
+	facility int
+	hostname string
+	appName  string
+	procID   string
+}
+
+// newSyslogSinkFromURL builds a syslog sink from a URL. See Open for the
+// supported forms and parameters.
+func (sr *sinkRegistry) newSyslogSinkFromURL(u *url.URL) (Sink, error) {
+	if u.User != nil {
+		return nil, fmt.Errorf("user and password not allowed with syslog URLs: got %v", u)
+	}
+	if u.Fragment != "" {
+		return nil, fmt.Errorf("fragments not allowed with syslog URLs: got %v", u)
+	}
+
+	cfg, err := parseSyslogURL(u)
+	if err != nil {
+		return nil, fmt.Errorf("invalid syslog URL %v: %w", u, err)
+	}
+
+	s := &syslogSink{cfg: cfg, clock: sr.clock}
+	if err := s.connect(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func parseSyslogURL(u *url.URL) (syslogConfig, error) {
+	cfg := syslogConfig{
+		facility: _syslogFacilities["user"],
+		appName:  filepath.Base(os.Args[0]),
+		procID:   strconv.Itoa(os.Getpid()),
+	}
+	if hn, err := os.Hostname(); err == nil {
+		cfg.hostname = hn
+	}
+
+	for key, vals := range u.Query() {
+		if len(vals) != 1 {
+			return cfg, fmt.Errorf("parameter %q must be specified exactly once", key)
+		}
+		val := vals[0]
+		switch key {
+		case "network":
+			cfg.network = val
+		case "format":
+			switch strings.ToLower(val) {
+			case "rfc5424":
+				cfg.format = syslogRFC5424
+			case "rfc3164":
+				cfg.format = syslogRFC3164
+			default:
+				return cfg, fmt.Errorf("unknown format %q", val)
+			}
+		case "facility":
+			f, ok := _syslogFacilities[strings.ToLower(val)]
+			if !ok {
+				return cfg, fmt.Errorf("unknown facility %q", val)
+			}
+			cfg.facility = f
+		case "hostname":
+			cfg.hostname = val
+		case "appName":
+			cfg.appName = val
+		case "procID":
+			cfg.procID = val
+		default:
+			return cfg, fmt.Errorf("unknown parameter %q", key)
+		}
+	}
+
+	if u.Host == "" {
+		// syslog:///dev/log addresses a local socket.
+		if u.Path == "" {
+			return cfg, errors.New("must specify a host or a socket path")
+		}
+		if cfg.network == "" {
+			cfg.network = "unixgram"
+		}
+		cfg.address = u.Path
+	} else {
+		if u.Path != "" && u.Path != "/" {
+			return cfg, errors.New("paths not allowed with a host")
+		}
+		if cfg.network == "" {
+			cfg.network = "udp"
+		}
+		cfg.address = u.Host
+		if u.Port() == "" {
+			cfg.address = net.JoinHostPort(u.Hostname(), _syslogDefaultPort)
+		}
+	}
+
+	switch cfg.network {
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6":
+		if u.Host == "" {
+			return cfg, fmt.Errorf("network %q requires a host", cfg.network)
+		}
+	case "unix", "unixgram":
+		if u.Host != "" {
+			return cfg, fmt.Errorf("network %q requires a socket path, not a host", cfg.network)
+		}
+	default:
+		return cfg, fmt.Errorf("unsupported network %q", cfg.network)
+	}
+
+	cfg.hostname = syslogField(cfg.hostname, 255)
+	cfg.appName = syslogField(cfg.appName, 48)
+	cfg.procID = syslogField(cfg.procID, 128)
+	return cfg, nil
+}
+
+// syslogField sanitizes a header field per RFC 5424: printable US-ASCII
+// without spaces, at most maxLen bytes, and "-" if empty.
+func syslogField(s string, maxLen int) string {
+	s = strings.Map(func(r rune) rune {
+		if r < '!' || r > '~' {
+			return -1
+		}
+		return r
+	}, s)
+	if len(s) > maxLen {
+		s = s[:maxLen]
+	}
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// syslogSink is a Sink that frames each entry as a syslog message.
+//
+// It implements zapcore.LevelWriter so that each message's severity matches
+// the level of the entry. Entries written without a level are sent with the
+// informational severity.
+type syslogSink struct {
+	cfg   syslogConfig
+	clock zapcore.Clock
+
+	mu     sync.Mutex
+	conn   net.Conn
+	closed bool
+	buf    bytes.Buffer
+}
+
+var (
+	_ Sink                = (*syslogSink)(nil)
+	_ zapcore.LevelWriter = (*syslogSink)(nil)
+)
+
+// stream reports whether messages need octet-counting framing (RFC 6587)
+// because the transport doesn't preserve message boundaries.
+func (s *syslogSink) stream() bool {
+	switch s.cfg.network {
+	case "tcp", "tcp4", "tcp6", "unix":
+		return true
+	}
+	return false
+}
+
+// connect dials the configured address.
+//
+// s.mu must be held, or s must not yet be shared.
+func (s *syslogSink) connect() error {
+	conn, err := net.DialTimeout(s.cfg.network, s.cfg.address, _syslogDialTimeout)
+	if err != nil {
+		return fmt.Errorf("can't connect to syslog at %v %v: %w", s.cfg.network, s.cfg.address, err)
+	}
+	s.conn = conn
+	return nil
+}
+
+// Write sends p as a single message with the informational severity.
+func (s *syslogSink) Write(p []byte) (int, error) {
+	return s.WriteLevel(zapcore.InfoLevel, p)
+}
+
+// WriteLevel sends p as a single message with the severity of lvl.
+func (s *syslogSink) WriteLevel(lvl zapcore.Level, p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return 0, errors.New("syslog sink is closed")
+	}
+
+	s.buf.Reset()
+	s.frame(lvl, bytes.TrimRight(p, "\r\n"))
+
+	if s.conn != nil {
+		if _, err := s.conn.Write(s.buf.Bytes()); err == nil {
+			return len(p), nil
+		}
+		// The connection is probably broken. Reconnect and try once more.
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+	if err := s.connect(); err != nil {
+		return 0, err
+	}
+	if _, err := s.conn.Write(s.buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// frame writes the message for msg into s.buf.
+//
+// s.mu must be held.
+func (s *syslogSink) frame(lvl zapcore.Level, msg []byte) {
+	pri := s.cfg.facility*8 + syslogSeverity(lvl)
+	now := s.clock.Now()
+
+	var header string
+	switch s.cfg.format {
+	case syslogRFC3164:
+		// <PRI>Mmm dd hh:mm:ss HOSTNAME TAG[PID]: MSG
+		header = fmt.Sprintf("<%d>%s %s %s[%s]: ",
+			pri, now.Format(time.Stamp), s.cfg.hostname, s.cfg.appName, s.cfg.procID)
+	default:
+		// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+		header = fmt.Sprintf("<%d>1 %s %s %s %s - - ",
+			pri, now.Format("2006-01-02T15:04:05.000000Z07:00"), s.cfg.hostname, s.cfg.appName, s.cfg.procID)
+	}
+
+	if s.stream() {
+		s.buf.WriteString(strconv.Itoa(len(header) + len(msg)))
+		s.buf.WriteByte(' ')
+	}
+	s.buf.WriteString(header)
+	s.buf.Write(msg)
+}
+
+// Sync is a no-op: messages are sent as soon as they're written.
+func (s *syslogSink) Sync() error {
+	return nil
+}
+
+// Close closes the connection to the syslog daemon.
+func (s *syslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"context"
+	"encoding/hex"
+)
+
+// TraceContext identifies a span in W3C Trace Context terms. Its fields use
+// the same representation as OpenTelemetry's trace.SpanContext, so the
+// values returned by its TraceID, SpanID, and TraceFlags methods can be
+// assigned to them directly.
+type TraceContext struct {
+	TraceID    [16]byte
+	SpanID     [8]byte
+	TraceFlags byte
+}
+
+// IsValid reports whether the trace and span IDs are both non-zero, as the
+// W3C Trace Context specification requires.
+func (tc TraceContext) IsValid() bool {
+	return tc.TraceID != [16]byte{} && tc.SpanID != [8]byte{}
+}
+
+// TraceKeys names the fields added by TraceFields. Empty keys fall back to
+// "trace_id", "span_id", and "trace_flags".
+type TraceKeys struct {
+	TraceID    string
+	SpanID     string
+	TraceFlags string
+}
+
+// TraceFields builds a context extractor that adds the trace ID, span ID,
+// and trace flags of the span in a context.Context as lowercase hex
+// strings, as they appear in W3C traceparent headers. Use it with
+// WithContextExtractor, or with zapslog.WithContextExtractor, to correlate
+// logs with traces.
+//
+// The spanContext function looks up the span in a context. It reports false,
+// or returns an invalid TraceContext, if there isn't one, in which case no
+// fields are added. This keeps zap free of tracing dependencies; with
+// OpenTelemetry, it looks like this:
+//
+//	spanContext := func(ctx context.Context) (zap.TraceContext, bool) {
+//		sc := trace.SpanContextFromContext(ctx)
+//		return zap.TraceContext{
+//			TraceID:    sc.TraceID(),
+//			SpanID:     sc.SpanID(),
+//			TraceFlags: byte(sc.TraceFlags()),
+//		}, sc.IsValid()
+//	}
+//	logger := zap.New(core, zap.WithContextExtractor(zap.TraceFields(spanContext, zap.TraceKeys{})))
+//	logger.Ctx(ctx).Info("handled request")
+//	// {"msg":"handled request","trace_id":"4bf92f3577b34da6a3ce929d0e0e4736","span_id":"00f067aa0ba902b7","trace_flags":"01"}
+func TraceFields(spanContext func(context.Context) (TraceContext, bool), keys TraceKeys) func(context.Context) []Field {
+	if keys.TraceID == "" {
+		keys.TraceID = "trace_id"
+	}
+	if keys.SpanID == "" {
+		keys.SpanID = "span_id"
+	}
+	if keys.TraceFlags == "" {
+		keys.TraceFlags = "trace_flags"
+	}
+
+	return func(ctx context.Context) []Field {
+		tc, ok := spanContext(ctx)
+		if !ok || !tc.IsValid() {
+			return nil
+		}
+		return []Field{
+			String(keys.TraceID, hex.EncodeToString(tc.TraceID[:])),
+			String(keys.SpanID, hex.EncodeToString(tc.SpanID[:])),
+			String(keys.TraceFlags, hex.EncodeToString([]byte{tc.TraceFlags})),
+		}
+	}
+}
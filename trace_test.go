@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zap
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type traceContextKey struct{}
+
+func contextWithTrace(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+func traceFromContext(ctx context.Context) (TraceContext, bool) {
+	tc, ok := ctx.Value(traceContextKey{}).(TraceContext)
+	return tc, ok
+}
+
+var _testTrace = TraceContext{
+	TraceID:    [16]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+	SpanID:     [8]byte{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	TraceFlags: 1,
+}
+
+func TestTraceFields(t *testing.T) {
+	tests := []struct {
+		desc string
+		ctx  context.Context
+		keys TraceKeys
+		want []Field
+	}{
+		{
+			desc: "default keys",
+			ctx:  contextWithTrace(context.Background(), _testTrace),
+			want: []Field{
+				String("trace_id", "4bf92f3577b34da6a3ce929d0e0e4736"),
+				String("span_id", "00f067aa0ba902b7"),
+				String("trace_flags", "01"),
+			},
+		},
+		{
+			desc: "custom keys",
+			ctx:  contextWithTrace(context.Background(), _testTrace),
+			keys: TraceKeys{TraceID: "traceId", SpanID: "spanId"},
+			want: []Field{
+				String("traceId", "4bf92f3577b34da6a3ce929d0e0e4736"),
+				String("spanId", "00f067aa0ba902b7"),
+				String("trace_flags", "01"),
+			},
+		},
+		{
+			desc: "no span",
+			ctx:  context.Background(),
+		},
+		{
+			desc: "invalid span",
+			ctx:  contextWithTrace(context.Background(), TraceContext{TraceID: _testTrace.TraceID}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, TraceFields(traceFromContext, tt.keys)(tt.ctx), "Unexpected fields.")
+		})
+	}
+}
+
+func TestTraceFieldsLogger(t *testing.T) {
+	extractor := WithContextExtractor(TraceFields(traceFromContext, TraceKeys{}))
+	withLogger(t, DebugLevel, opts(extractor), func(logger *Logger, logs *observer.ObservedLogs) {
+		ctx := contextWithTrace(context.Background(), _testTrace)
+		logger.Ctx(ctx).Info("logger")
+		logger.Sugar().Ctx(ctx).Infow("sugared", "k", "v")
+		logger.Ctx(context.Background()).Info("untraced")
+
+		entries := logs.AllUntimed()
+		require.Len(t, entries, 3, "Unexpected number of entries.")
+		for _, ent := range entries[:2] {
+			assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", ent.ContextMap()["trace_id"], "Missing trace ID in %q.", ent.Message)
+			assert.Equal(t, "00f067aa0ba902b7", ent.ContextMap()["span_id"], "Missing span ID in %q.", ent.Message)
+		}
+		assert.Empty(t, entries[2].Context, "Expected no trace fields without a span.")
+	})
+}
@@ -35,19 +35,91 @@ import (
 // any opened files.
 //
 // Passing no URLs returns a no-op WriteSyncer. Zap handles URLs without a
-// scheme and URLs with the "file" scheme. Third-party code may register
-// factories for other schemes using RegisterSink.
+// scheme and URLs with the "file", "rotate", "syslog", "tcp" and "udp"
+// schemes. Third-party code may register factories for other schemes using
+// RegisterSink.
 //
 // URLs with the "file" scheme must use absolute paths on the local
 // filesystem. No user, password, port, fragments, or query parameters are
 // allowed, and the hostname must be empty or "localhost".
 //
+// URLs with the "rotate" scheme follow the same rules as "file" URLs, but
+// accept query parameters that control rotation of the file:
+//
+//   - maxSize: rotate once the file would exceed this size, for example
+//     "512KB", "100MB" or "1GB". Units are powers of 1024.
+//   - interval: rotate at every multiple of this duration on the wall clock,
+//     for example "1h" or "24h".
+//   - maxBackups: keep at most this many rotated files.
+//   - maxAge: remove rotated files older than this duration, for example
+//     "168h".
+//   - compress: gzip rotated files if "true".
+//   - localTime: use local time rather than UTC for rotation boundaries and
+//     backup names if "true".
+//
+// For example,
+//
+//	rotate:///var/log/app.log?maxSize=100MB&maxBackups=7&compress=true
+//
+// Rotated files are named after the original file and the time of rotation,
+// for example "/var/log/app-2006-01-02T15-04-05.000.log".
+//
+// URLs with the "syslog" scheme send each entry to a syslog daemon, with a
+// severity derived from the entry's level. Without a host, the path names a
+// local socket, as in "syslog:///dev/log"; otherwise the host and port
+// (default 514) name a remote daemon, as in "syslog://logs.example.com:514".
+// The following query parameters are supported:
+//
+//   - network: "unixgram" (the default for sockets), "unix", "udp" (the
+//     default for hosts) or "tcp". Messages sent over "unix" and "tcp" use
+//     octet-counting framing.
+//   - format: "rfc5424" (the default) or "rfc3164".
+//   - facility: a facility name such as "user" (the default), "daemon" or
+//     "local0".
+//   - appName, procID and hostname: override the application name, process
+//     ID and hostname in the message header. They default to the name of the
+//     executable, the process ID and the system hostname.
+//
+// URLs with the "tcp" and "udp" schemes, such as "tcp://logs.example.com:9000",
+// send each entry to a network peer. Entries are queued in memory and sent
+// from a background goroutine, which reconnects with exponential backoff if
+// the connection fails. The following query parameters are supported:
+//
+//   - queueSize: the number of entries to hold while disconnected. Defaults
+//     to 1024.
+//   - overflow: what to do with new entries when the queue is full:
+//     "dropOldest" (the default), "dropNewest" or "block".
+//   - minBackoff and maxBackoff: the bounds of the delay between connection
+//     attempts. Default to "100ms" and "30s".
+//   - timeout: the timeout for connecting and for each write. Defaults to
+//     "5s".
+//
+// Sync on these sinks waits for the queue to drain while connected, and
+// reports entries dropped since the previous Sync as an error.
+//
 // Since it's common to write logs to the local filesystem, URLs without a
 // scheme (e.g., "/var/log/foo.log") are treated as local file paths. Without
 // a scheme, the special paths "stdout" and "stderr" are interpreted as
 // os.Stdout and os.Stderr. When specified without a scheme, relative file
 // paths also work.
+//
+// Files opened with or without the "file" scheme, as well as those opened
+// with the "rotate" scheme, can be reopened at their original paths with
+// ReopenSinks, for use with external rotation tools such as logrotate.
 func Open(paths ...string) (zapcore.WriteSyncer, func(), error) {
+	writer, closeAll, err := openCombined(paths)
+	if err != nil {
+		return nil, nil, err
+	}
+	return writer, func() { _ = closeAll() }, nil
+}
+
+// sinkOpener opens the sinks for a list of paths and combines them, like
+// openCombined.
+type sinkOpener func(paths []string) (zapcore.WriteSyncer, func() error, error)
+
+// openCombined is like Open, but its close function reports errors.
+func openCombined(paths []string) (zapcore.WriteSyncer, func() error, error) {
 	writers, closeAll, err := open(paths)
 	if err != nil {
 		return nil, nil, err
@@ -57,13 +129,15 @@ func Open(paths ...string) (zapcore.WriteSyncer, func(), error) {
 	return writer, closeAll, nil
 }
 
-func open(paths []string) ([]zapcore.WriteSyncer, func(), error) {
+func open(paths []string) ([]zapcore.WriteSyncer, func() error, error) {
 	writers := make([]zapcore.WriteSyncer, 0, len(paths))
 	closers := make([]io.Closer, 0, len(paths))
-	closeAll := func() {
+	closeAll := func() error {
+		var err error
 		for _, c := range closers {
-			_ = c.Close()
+			err = multierr.Append(err, c.Close())
 		}
+		return err
 	}
 
 	var openErr error
@@ -77,7 +151,7 @@ func open(paths []string) ([]zapcore.WriteSyncer, func(), error) {
 		closers = append(closers, sink)
 	}
 	if openErr != nil {
-		closeAll()
+		_ = closeAll()
 		return nil, nil, openErr
 	}
 
@@ -0,0 +1,350 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+const _defaultAsyncBufferSize = 1024
+
+// AsyncOverflowPolicy decides what an AsyncCore does with a new entry when
+// its queue is full.
+type AsyncOverflowPolicy int
+
+const (
+	// AsyncBlock makes the caller wait until there's room in the queue.
+	AsyncBlock AsyncOverflowPolicy = iota
+	// AsyncDropNewest drops the new entry.
+	AsyncDropNewest
+	// AsyncDropOldest drops the oldest entry in the queue to make room for
+	// the new one.
+	AsyncDropOldest
+)
+
+// AsyncOption configures an AsyncCore.
+type AsyncOption interface {
+	apply(*asyncQueue)
+}
+
+type asyncOptionFunc func(*asyncQueue)
+
+func (f asyncOptionFunc) apply(q *asyncQueue) {
+	f(q)
+}
+
+// AsyncBufferSize sets the maximum number of entries an AsyncCore holds
+// before applying its overflow policy. It defaults to 1024.
+func AsyncBufferSize(n int) AsyncOption {
+	return asyncOptionFunc(func(q *asyncQueue) {
+		if n > 0 {
+			q.size = n
+		}
+	})
+}
+
+// AsyncOverflow sets what an AsyncCore does when its queue is full. It
+// defaults to AsyncBlock.
+func AsyncOverflow(policy AsyncOverflowPolicy) AsyncOption {
+	return asyncOptionFunc(func(q *asyncQueue) {
+		q.overflow = policy
+	})
+}
+
+// AsyncDropBelow makes an AsyncCore drop entries below the given level when
+// its queue is full, regardless of the overflow policy. Entries at or above
+// the level are still subject to the overflow policy.
+//
+// For example, the following never blocks on debug and info logs, but waits
+// for room in the queue for warnings and errors:
+//
+//	NewAsyncCore(core, AsyncOverflow(AsyncBlock), AsyncDropBelow(WarnLevel))
+func AsyncDropBelow(lvl Level) AsyncOption {
+	return asyncOptionFunc(func(q *asyncQueue) {
+		q.dropBelow = lvl
+	})
+}
+
+// AsyncErrorOutput sets where an AsyncCore reports errors returned by the
+// wrapped Core. Since entries are written in the background, these errors
+// can't be reported to the logger's own error output. By default they're
+// only counted.
+func AsyncErrorOutput(ws WriteSyncer) AsyncOption {
+	return asyncOptionFunc(func(q *asyncQueue) {
+		q.errorOutput = ws
+	})
+}
+
+// AsyncStats is a snapshot of the counters of an AsyncCore.
+type AsyncStats struct {
+	// Enqueued is the number of entries accepted into the queue.
+	Enqueued uint64
+	// Dropped is the number of entries dropped because the queue was full,
+	// including those removed from the queue by AsyncDropOldest.
+	Dropped uint64
+	// Written is the number of entries written to the wrapped Core.
+	Written uint64
+	// Failed is the number of entries the wrapped Core failed to write.
+	Failed uint64
+}
+
+// An AsyncCore is a Core that encodes and writes entries on a background
+// goroutine, so that logging never waits on the wrapped Core unless the
+// queue is full and the overflow policy is AsyncBlock.
+//
+// Entries are queued with the Fields passed to Write, and those Fields are
+// encoded later. Objects, arrays, Stringers and other values logged by
+// reference must not be modified after they're logged.
+//
+// Entries above ErrorLevel may end the process, so the AsyncCore drains its
+// queue and writes them synchronously.
+//
+// Call Stop to write any queued entries and stop the background goroutine
+// once the AsyncCore is no longer needed. Cores derived with With share the
+// queue and the goroutine of the AsyncCore they were derived from.
+type AsyncCore struct {
+	core Core
+	q    *asyncQueue
+}
+
+var (
+	_ Core           = (*AsyncCore)(nil)
+	_ leveledEnabler = (*AsyncCore)(nil)
+)
+
+// NewAsyncCore wraps a Core so that entries are written in the background.
+// See AsyncCore for details.
+func NewAsyncCore(core Core, opts ...AsyncOption) *AsyncCore {
+	q := &asyncQueue{
+		size:      _defaultAsyncBufferSize,
+		overflow:  AsyncBlock,
+		dropBelow: _minLevel,
+		done:      make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt.apply(q)
+	}
+	q.items = make([]asyncItem, q.size)
+	q.cond = sync.NewCond(&q.mu)
+	go q.run()
+
+	return &AsyncCore{core: core, q: q}
+}
+
+// Enabled reports whether the wrapped Core is enabled at the given level.
+func (c *AsyncCore) Enabled(lvl Level) bool {
+	return c.core.Enabled(lvl)
+}
+
+// Level reports the minimum enabled level of the wrapped Core.
+func (c *AsyncCore) Level() Level {
+	return LevelOf(c.core)
+}
+
+// With adds structured context to the wrapped Core. The returned Core shares
+// the queue of this one.
+func (c *AsyncCore) With(fields []Field) Core {
+	return &AsyncCore{core: c.core.With(fields), q: c.q}
+}
+
+// Check adds the AsyncCore to the CheckedEntry if the wrapped Core is
+// enabled at the entry's level. The wrapped Core's own Check, which may
+// sample or filter the entry, runs when the entry is written.
+func (c *AsyncCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write queues the entry to be written in the background. It returns an
+// error only for entries written synchronously.
+func (c *AsyncCore) Write(ent Entry, fields []Field) error {
+	if ent.Level > ErrorLevel {
+		// The process may be about to exit: write everything now.
+		c.q.drain()
+		return c.q.write(asyncItem{core: c.core, ent: ent, fields: fields})
+	}
+
+	// The caller may reuse the fields slice once we return.
+	item := asyncItem{core: c.core, ent: ent, fields: append([]Field(nil), fields...)}
+	if !c.q.push(item) {
+		// Stopped: there's no background goroutine to write the entry.
+		return c.q.write(item)
+	}
+	return nil
+}
+
+// Sync waits for every entry queued so far to be written, then syncs the
+// wrapped Core.
+func (c *AsyncCore) Sync() error {
+	c.q.drain()
+	return c.core.Sync()
+}
+
+// Stop writes any queued entries, stops the background goroutine, and syncs
+// the wrapped Core. Entries logged after Stop are written synchronously.
+// Calling Stop more than once is safe.
+func (c *AsyncCore) Stop() error {
+	c.q.stop()
+	return c.core.Sync()
+}
+
+// Stats reports the counters of the queue shared by this AsyncCore and the
+// Cores derived from it.
+func (c *AsyncCore) Stats() AsyncStats {
+	return AsyncStats{
+		Enqueued: c.q.enqueued.Load(),
+		Dropped:  c.q.dropped.Load(),
+		Written:  c.q.written.Load(),
+		Failed:   c.q.failed.Load(),
+	}
+}
+
+type asyncItem struct {
+	core   Core
+	ent    Entry
+	fields []Field
+}
+
+// asyncQueue is a bounded FIFO of entries shared by an AsyncCore and the
+// Cores derived from it, together with the goroutine that writes them.
+type asyncQueue struct {
+	size        int
+	overflow    AsyncOverflowPolicy
+	dropBelow   Level
+	errorOutput WriteSyncer
+
+	enqueued, dropped, written, failed atomic.Uint64
+
+	mu       sync.Mutex
+	cond     *sync.Cond  // signaled whenever the state below changes
+	items    []asyncItem // ring buffer
+	head, n  int         // index of the oldest item and number of items
+	inFlight int         // items taken by run but not yet written
+	stopped  bool
+	done     chan struct{} // closed when run returns
+}
+
+// push adds an item to the queue, applying the overflow policy if it's
+// full. It returns false if the queue has been stopped.
+func (q *asyncQueue) push(item asyncItem) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for !q.stopped && q.n == len(q.items) {
+		switch {
+		case item.ent.Level < q.dropBelow || q.overflow == AsyncDropNewest:
+			q.dropped.Add(1)
+			return true
+		case q.overflow == AsyncDropOldest:
+			q.items[q.head] = asyncItem{}
+			q.head = (q.head + 1) % len(q.items)
+			q.n--
+			q.dropped.Add(1)
+		default: // AsyncBlock
+			q.cond.Wait()
+		}
+	}
+	if q.stopped {
+		return false
+	}
+
+	q.items[(q.head+q.n)%len(q.items)] = item
+	q.n++
+	q.enqueued.Add(1)
+	q.cond.Broadcast()
+	return true
+}
+
+// drain waits until every item queued so far has been written.
+func (q *asyncQueue) drain() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.n > 0 || q.inFlight > 0 {
+		q.cond.Wait()
+	}
+}
+
+func (q *asyncQueue) stop() {
+	q.mu.Lock()
+	q.stopped = true
+	q.cond.Broadcast()
+	q.mu.Unlock()
+
+	<-q.done
+}
+
+// run writes queued items until the queue is stopped and empty.
+func (q *asyncQueue) run() {
+	defer close(q.done)
+
+	var batch []asyncItem
+	for {
+		q.mu.Lock()
+		for q.n == 0 && !q.stopped {
+			q.cond.Wait()
+		}
+		if q.n == 0 {
+			q.mu.Unlock()
+			return
+		}
+
+		// Take everything that's queued so that writers contend for the
+		// lock once per batch rather than once per entry.
+		batch = batch[:0]
+		for ; q.n > 0; q.n-- {
+			batch = append(batch, q.items[q.head])
+			q.items[q.head] = asyncItem{}
+			q.head = (q.head + 1) % len(q.items)
+		}
+		q.inFlight = len(batch)
+		q.cond.Broadcast() // there's room in the queue now
+		q.mu.Unlock()
+
+		for i := range batch {
+			if err := q.write(batch[i]); err != nil && q.errorOutput != nil {
+				_, _ = fmt.Fprintf(q.errorOutput, "%v async write error: %v\n", batch[i].ent.Time, err)
+				_ = q.errorOutput.Sync()
+			}
+			batch[i] = asyncItem{}
+		}
+
+		q.mu.Lock()
+		q.inFlight = 0
+		q.cond.Broadcast()
+		q.mu.Unlock()
+	}
+}
+
+// write checks and writes a single item to its Core and updates the
+// counters.
+func (q *asyncQueue) write(item asyncItem) error {
+	if err := checkAndWrite(item.core, item.ent, item.fields); err != nil {
+		q.failed.Add(1)
+		return err
+	}
+	q.written.Add(1)
+	return nil
+}
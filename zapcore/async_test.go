@@ -0,0 +1,285 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap/internal/ztest"
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// gatedCore is a Core whose writes block until it's opened.
+type gatedCore struct {
+	Core
+
+	entered chan struct{} // receives a value whenever a write starts
+	gate    chan struct{} // closed to let writes through
+}
+
+func newGatedCore(core Core) *gatedCore {
+	return &gatedCore{
+		Core:    core,
+		entered: make(chan struct{}, 100),
+		gate:    make(chan struct{}),
+	}
+}
+
+func (c *gatedCore) With(fields []Field) Core {
+	return &gatedCore{Core: c.Core.With(fields), entered: c.entered, gate: c.gate}
+}
+
+func (c *gatedCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *gatedCore) Write(ent Entry, fields []Field) error {
+	c.entered <- struct{}{}
+	<-c.gate
+	return c.Core.Write(ent, fields)
+}
+
+func (c *gatedCore) open() { close(c.gate) }
+
+func messages(logs *observer.ObservedLogs) []string {
+	var msgs []string
+	for _, e := range logs.AllUntimed() {
+		msgs = append(msgs, e.Message)
+	}
+	return msgs
+}
+
+func writeEntry(core Core, lvl Level, msg string) {
+	if ce := core.Check(Entry{Level: lvl, Message: msg}, nil); ce != nil {
+		ce.Write()
+	}
+}
+
+// stalledAsyncCore returns an AsyncCore with a queue of two whose
+// background goroutine is stuck writing the entry "stalled".
+func stalledAsyncCore(t testing.TB, opts ...AsyncOption) (*AsyncCore, *gatedCore, *observer.ObservedLogs) {
+	obs, logs := observer.New(DebugLevel)
+	gated := newGatedCore(obs)
+	async := NewAsyncCore(gated, append([]AsyncOption{AsyncBufferSize(2)}, opts...)...)
+	t.Cleanup(func() { assert.NoError(t, async.Stop()) })
+
+	writeEntry(async, InfoLevel, "stalled")
+	<-gated.entered
+	return async, gated, logs
+}
+
+func TestAsyncCore(t *testing.T) {
+	obs, logs := observer.New(InfoLevel)
+	async := NewAsyncCore(obs)
+	defer async.Stop()
+
+	assert.Equal(t, InfoLevel, LevelOf(async), "Unexpected level.")
+	assert.False(t, async.Enabled(DebugLevel), "Expected debug to be disabled.")
+
+	child := async.With([]Field{makeInt64Field("k", 1)})
+	writeEntry(async, DebugLevel, "debug")
+	writeEntry(async, InfoLevel, "info")
+	writeEntry(child, WarnLevel, "warn")
+	require.NoError(t, async.Sync(), "Unexpected error syncing.")
+
+	assert.Equal(t, []observer.LoggedEntry{
+		{Entry: Entry{Level: InfoLevel, Message: "info"}, Context: []Field{}},
+		{Entry: Entry{Level: WarnLevel, Message: "warn"}, Context: []Field{makeInt64Field("k", 1)}},
+	}, logs.AllUntimed(), "Unexpected entries.")
+	assert.Equal(t, AsyncStats{Enqueued: 2, Written: 2}, async.Stats(), "Unexpected stats.")
+}
+
+func TestAsyncCoreCopiesFields(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	gated := newGatedCore(obs)
+	async := NewAsyncCore(gated)
+	defer async.Stop()
+
+	fields := []Field{makeInt64Field("k", 1)}
+	require.NoError(t, async.Write(Entry{Message: "foo"}, fields))
+	fields[0] = makeInt64Field("k", 2)
+
+	gated.open()
+	require.NoError(t, async.Sync())
+	assert.Equal(t, []Field{makeInt64Field("k", 1)}, logs.AllUntimed()[0].Context,
+		"Expected fields to be copied when queued.")
+}
+
+func TestAsyncCoreOverflow(t *testing.T) {
+	tests := []struct {
+		desc     string
+		opts     []AsyncOption
+		want     []string
+		enqueued uint64
+	}{
+		{
+			desc:     "drop newest",
+			opts:     []AsyncOption{AsyncOverflow(AsyncDropNewest)},
+			want:     []string{"stalled", "1", "2"},
+			enqueued: 3,
+		},
+		{
+			desc:     "drop oldest",
+			opts:     []AsyncOption{AsyncOverflow(AsyncDropOldest)},
+			want:     []string{"stalled", "3", "4"},
+			enqueued: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			async, gated, logs := stalledAsyncCore(t, tt.opts...)
+			for i := 1; i <= 4; i++ {
+				writeEntry(async, InfoLevel, fmt.Sprint(i))
+			}
+
+			gated.open()
+			require.NoError(t, async.Sync(), "Unexpected error syncing.")
+			assert.Equal(t, tt.want, messages(logs), "Unexpected entries.")
+			assert.Equal(t, AsyncStats{Enqueued: tt.enqueued, Dropped: 2, Written: 3}, async.Stats(), "Unexpected stats.")
+		})
+	}
+}
+
+func TestAsyncCoreOverflowBlock(t *testing.T) {
+	async, gated, logs := stalledAsyncCore(t, AsyncDropBelow(WarnLevel))
+	writeEntry(async, InfoLevel, "1")
+	writeEntry(async, InfoLevel, "2")
+	writeEntry(async, InfoLevel, "dropped")
+
+	blocked := make(chan struct{})
+	go func() {
+		defer close(blocked)
+		writeEntry(async, WarnLevel, "blocked")
+	}()
+
+	select {
+	case <-blocked:
+		t.Fatal("Expected warning to wait for room in the queue.")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	gated.open()
+	<-blocked
+	require.NoError(t, async.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, []string{"stalled", "1", "2", "blocked"}, messages(logs), "Unexpected entries.")
+	assert.Equal(t, uint64(1), async.Stats().Dropped, "Expected info entry to be dropped.")
+}
+
+func TestAsyncCoreChecksWrappedCore(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	async := NewAsyncCore(NewSamplerWithOptions(obs, time.Minute, 1, 0))
+
+	for i := 0; i < 5; i++ {
+		if ce := async.Check(Entry{Level: InfoLevel, Message: "msg", Time: time.Now()}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+	require.NoError(t, async.Stop(), "Unexpected error stopping.")
+	assert.Equal(t, []string{"msg"}, messages(logs), "Expected the wrapped sampler to drop repeats.")
+}
+
+func TestAsyncCoreWritesFatalSynchronously(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	async := NewAsyncCore(obs)
+	defer async.Stop()
+
+	for i := 0; i < 10; i++ {
+		writeEntry(async, InfoLevel, fmt.Sprint(i))
+	}
+	require.NoError(t, async.Write(Entry{Level: FatalLevel, Message: "fatal"}, nil))
+
+	// No Sync: everything must already be written.
+	msgs := messages(logs)
+	require.Len(t, msgs, 11, "Expected queued entries to be written before the fatal one.")
+	assert.Equal(t, "fatal", msgs[10], "Expected fatal entry to be written last.")
+}
+
+func TestAsyncCoreWriteErrors(t *testing.T) {
+	errOut := &ztest.Buffer{}
+	failing := NewCore(NewJSONEncoder(EncoderConfig{}), &ztest.FailWriter{}, DebugLevel)
+	async := NewAsyncCore(failing, AsyncErrorOutput(errOut))
+	defer async.Stop()
+
+	require.NoError(t, async.Write(Entry{Message: "foo"}, nil), "Expected queued writes to succeed.")
+	require.NoError(t, async.Sync(), "Unexpected error syncing.")
+
+	assert.Contains(t, errOut.String(), "async write error: failed", "Expected error to be reported.")
+	assert.Equal(t, AsyncStats{Enqueued: 1, Failed: 1}, async.Stats(), "Unexpected stats.")
+}
+
+func TestAsyncCoreStop(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	sink := &ztest.Buffer{}
+	async := NewAsyncCore(NewTee(obs, NewCore(NewJSONEncoder(EncoderConfig{}), sink, DebugLevel)))
+
+	writeEntry(async, InfoLevel, "before")
+	require.NoError(t, async.Stop(), "Unexpected error stopping.")
+	assert.Equal(t, []string{"before"}, messages(logs), "Expected Stop to write queued entries.")
+	assert.True(t, sink.Called(), "Expected Stop to sync the wrapped core.")
+
+	writeEntry(async, InfoLevel, "after")
+	assert.Equal(t, []string{"before", "after"}, messages(logs), "Expected entries after Stop to be written synchronously.")
+	assert.NoError(t, async.Stop(), "Expected stopping twice to succeed.")
+}
+
+func TestAsyncCoreConcurrent(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	async := NewAsyncCore(obs, AsyncBufferSize(8))
+	defer async.Stop()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				writeEntry(async, InfoLevel, "msg")
+				if j%10 == 0 {
+					assert.NoError(t, async.Sync())
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, async.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, 800, logs.Len(), "Expected every entry to be written.")
+}
+
+func TestAsyncCoreSyncError(t *testing.T) {
+	sink := &ztest.Buffer{}
+	sink.SetError(errors.New("sync failed"))
+	async := NewAsyncCore(NewCore(NewJSONEncoder(EncoderConfig{}), sink, DebugLevel))
+	assert.ErrorContains(t, async.Sync(), "sync failed", "Expected Sync to propagate errors.")
+	assert.ErrorContains(t, async.Stop(), "sync failed", "Expected Stop to propagate errors.")
+}
@@ -55,9 +55,14 @@ func (nopCore) Write(Entry, []Field) error                    { return nil }
 func (nopCore) Sync() error                                   { return nil }
 
 // NewCore creates a Core that writes logs to a WriteSyncer.
+//
+// If enab is a NamedLevelEnabler, the Core also checks whether each entry's
+// level is enabled for the entry's logger name.
 func NewCore(enc Encoder, ws WriteSyncer, enab LevelEnabler) Core {
+	named, _ := enab.(NamedLevelEnabler)
 	return &ioCore{
 		LevelEnabler: enab,
+		named:        named,
 		enc:          enc,
 		out:          ws,
 	}
@@ -65,8 +70,9 @@ func NewCore(enc Encoder, ws WriteSyncer, enab LevelEnabler) Core {
 
 type ioCore struct {
 	LevelEnabler
-	enc Encoder
-	out WriteSyncer
+	named NamedLevelEnabler // nil unless LevelEnabler is a NamedLevelEnabler
+	enc   Encoder
+	out   WriteSyncer
 }
 
 var (
@@ -85,6 +91,12 @@ func (c *ioCore) With(fields []Field) Core {
 }
 
 func (c *ioCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.named != nil {
+		if c.named.EnabledFor(ent.LoggerName, ent.Level) {
+			return ce.AddCore(ent, c)
+		}
+		return ce
+	}
 	if c.Enabled(ent.Level) {
 		return ce.AddCore(ent, c)
 	}
@@ -96,7 +108,7 @@ func (c *ioCore) Write(ent Entry, fields []Field) error {
 	if err != nil {
 		return err
 	}
-	_, err = c.out.Write(buf.Bytes())
+	_, err = writeLevel(c.out, ent.Level, buf.Bytes())
 	buf.Free()
 	if err != nil {
 		return err
@@ -116,6 +128,7 @@ func (c *ioCore) Sync() error {
 func (c *ioCore) clone() *ioCore {
 	return &ioCore{
 		LevelEnabler: c.LevelEnabler,
+		named:        c.named,
 		enc:          c.enc.Clone(),
 		out:          c.out,
 	}
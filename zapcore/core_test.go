@@ -108,6 +108,29 @@ func TestIOCore(t *testing.T) {
 	)
 }
 
+// debugForDB enables DebugLevel for the logger named "db" and InfoLevel
+// for all others.
+type debugForDB struct{}
+
+func (debugForDB) Enabled(lvl Level) bool { return lvl >= DebugLevel }
+
+func (debugForDB) EnabledFor(name string, lvl Level) bool {
+	if name == "db" {
+		return lvl >= DebugLevel
+	}
+	return lvl >= InfoLevel
+}
+
+func TestIOCoreNamedLevelEnabler(t *testing.T) {
+	core := NewCore(NewJSONEncoder(testEncoderConfig()), &ztest.Discarder{}, debugForDB{}).
+		With([]Field{makeInt64Field("k", 1)})
+
+	assert.True(t, core.Enabled(DebugLevel), "Expected debug to be enabled for some logger.")
+	assert.Nil(t, core.Check(Entry{Level: DebugLevel}, nil), "Expected debug to be disabled for unnamed loggers.")
+	assert.NotNil(t, core.Check(Entry{Level: InfoLevel}, nil), "Expected info to be enabled for unnamed loggers.")
+	assert.NotNil(t, core.Check(Entry{Level: DebugLevel, LoggerName: "db"}, nil), "Expected debug to be enabled for db.")
+}
+
 func TestIOCoreSyncFail(t *testing.T) {
 	sink := &ztest.Discarder{}
 	err := errors.New("failed")
@@ -0,0 +1,338 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/internal/bufferpool"
+)
+
+// DedupOption configures a deduplicating Core created with NewDedupCore.
+type DedupOption interface {
+	apply(*dedupState)
+}
+
+type dedupOptionFunc func(*dedupState)
+
+func (f dedupOptionFunc) apply(s *dedupState) {
+	f(s)
+}
+
+// DedupClock sets the Clock used to timestamp summaries, to decide when a
+// window has ended, and to schedule summaries. It defaults to DefaultClock.
+func DedupClock(clock Clock) DedupOption {
+	return dedupOptionFunc(func(s *dedupState) {
+		s.clock = clock
+	})
+}
+
+// DedupCompareFields makes the Core compare the Fields passed at the log
+// site, in addition to the level, message, and caller, when deciding
+// whether two entries are identical. Comparing fields requires encoding
+// them, so it's more expensive.
+func DedupCompareFields() DedupOption {
+	return dedupOptionFunc(func(s *dedupState) {
+		s.compareFields = true
+	})
+}
+
+// NewDedupCore creates a Core that collapses repeated entries. Entries are
+// identical if they have the same level, logger name, message, caller, and
+// context added with With.
+// The first entry is written as usual, and identical entries logged within
+// window of it are suppressed. Once the window has passed, the Core writes
+// a summary like
+//
+//	{"level":"error","msg":"message repeated 1432 times","repeatedMessage":"connection refused","count":1432,"firstSeen":...,"lastSeen":...}
+//
+// where firstSeen and lastSeen are the times of the first and last
+// suppressed entries. Summaries are written by a timer once the window has
+// passed, or earlier by Sync. While entries are suppressed, the timer holds
+// a goroutine; Sync releases it.
+//
+// Unlike a sampler, which drops entries without a trace, the summaries tell
+// a one-off failure apart from a storm.
+//
+// Cores derived from the returned Core with With share its state, and
+// summaries are written with their context.
+func NewDedupCore(core Core, window time.Duration, opts ...DedupOption) Core {
+	s := &dedupState{
+		window:  window,
+		clock:   DefaultClock,
+		entries: make(map[string]*dedupEntry),
+	}
+	for _, opt := range opts {
+		opt.apply(s)
+	}
+	return &dedupCore{Core: core, s: s}
+}
+
+type dedupCore struct {
+	Core
+
+	s       *dedupState
+	context string // fields added with With, JSON-encoded
+}
+
+var (
+	_ Core           = (*dedupCore)(nil)
+	_ leveledEnabler = (*dedupCore)(nil)
+)
+
+// dedupState is shared by a deduplicating Core and the Cores derived from it.
+type dedupState struct {
+	window        time.Duration
+	clock         Clock
+	compareFields bool
+
+	mu        sync.Mutex
+	entries   map[string]*dedupEntry
+	nextSweep time.Time     // when to next look for expired entries
+	timer     chan struct{} // closed to cancel the pending timer; nil if none
+}
+
+// dedupEntry tracks the entries identical to one that was written.
+type dedupEntry struct {
+	core      Core  // the Core that wrote the first entry
+	ent       Entry // the first entry
+	fields    []Field
+	expires   time.Time
+	count     int // suppressed since the last summary
+	firstSeen time.Time
+	lastSeen  time.Time
+}
+
+func (c *dedupCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *dedupCore) With(fields []Field) Core {
+	return &dedupCore{
+		Core:    c.Core.With(fields),
+		s:       c.s,
+		context: encodeDedupFields(c.context, fields),
+	}
+}
+
+func (c *dedupCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	// The decision needs the caller, which is only known in Write.
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *dedupCore) Write(ent Entry, fields []Field) error {
+	key := c.s.key(ent, c.context, fields)
+	now := c.s.clock.Now()
+
+	c.s.mu.Lock()
+	summaries := c.s.sweepLocked(now)
+	e, ok := c.s.entries[key]
+	if ok && now.Before(e.expires) {
+		if e.count == 0 {
+			e.firstSeen = now
+		}
+		e.count++
+		e.lastSeen = now
+		c.s.scheduleLocked(e.expires)
+		c.s.mu.Unlock()
+		return c.s.writeSummaries(summaries, now)
+	}
+	if ok && e.count > 0 {
+		summaries = append(summaries, *e)
+	}
+	e = &dedupEntry{core: c.Core, ent: ent, expires: now.Add(c.s.window)}
+	if c.s.compareFields {
+		e.fields = append([]Field(nil), fields...)
+	}
+	c.s.entries[key] = e
+	c.s.mu.Unlock()
+
+	err := c.s.writeSummaries(summaries, now)
+	return multierr.Append(err, checkAndWrite(c.Core, ent, fields))
+}
+
+// Sync writes summaries of all entries suppressed so far, then syncs the
+// wrapped Core. Entries identical to ones written before Sync are still
+// suppressed until their windows end.
+func (c *dedupCore) Sync() error {
+	now := c.s.clock.Now()
+
+	c.s.mu.Lock()
+	c.s.cancelLocked()
+	var summaries []dedupEntry
+	for _, e := range c.s.entries {
+		if e.count > 0 {
+			summaries = append(summaries, *e)
+			e.count = 0
+		}
+	}
+	c.s.mu.Unlock()
+
+	err := c.s.writeSummaries(summaries, now)
+	return multierr.Append(err, c.Core.Sync())
+}
+
+// key identifies entries that are considered identical.
+func (s *dedupState) key(ent Entry, context string, fields []Field) string {
+	buf := bufferpool.Get()
+	defer buf.Free()
+
+	buf.AppendInt(int64(ent.Level))
+	buf.AppendByte(0)
+	buf.AppendString(ent.LoggerName)
+	buf.AppendByte(0)
+	buf.AppendString(ent.Message)
+	buf.AppendByte(0)
+	if ent.Caller.Defined {
+		buf.AppendString(ent.Caller.File)
+		buf.AppendByte(':')
+		buf.AppendInt(int64(ent.Caller.Line))
+	}
+	buf.AppendByte(0)
+	buf.AppendString(context)
+	if s.compareFields && len(fields) > 0 {
+		buf.AppendByte(0)
+		buf.AppendString(encodeDedupFields("", fields))
+	}
+	return buf.String()
+}
+
+// encodeDedupFields appends the JSON encoding of fields to prefix, which
+// must have been returned by an earlier call.
+func encodeDedupFields(prefix string, fields []Field) string {
+	enc := newJSONEncoder(EncoderConfig{}, false)
+	defer putJSONEncoder(enc)
+	defer enc.buf.Free()
+
+	enc.buf.AppendString(prefix)
+	addFields(enc, fields)
+	return enc.buf.String()
+}
+
+// scheduleLocked arranges for summaries to be written at the given time,
+// unless a timer is already pending. The timer reschedules itself while
+// suppressed entries remain.
+func (s *dedupState) scheduleLocked(at time.Time) {
+	if s.timer != nil {
+		return
+	}
+	d := at.Sub(s.clock.Now())
+	if d <= 0 {
+		d = time.Nanosecond
+	}
+	cancel := make(chan struct{})
+	s.timer = cancel
+	ticker := s.clock.NewTicker(d)
+	go func() {
+		defer ticker.Stop()
+		select {
+		case <-ticker.C:
+			s.flush(cancel)
+		case <-cancel:
+		}
+	}()
+}
+
+// cancelLocked stops the pending timer, if any.
+func (s *dedupState) cancelLocked() {
+	if s.timer != nil {
+		close(s.timer)
+		s.timer = nil
+	}
+}
+
+// flush writes summaries of the windows that have ended. It's called by the
+// timer identified by cancel.
+func (s *dedupState) flush(cancel chan struct{}) {
+	now := s.clock.Now()
+
+	s.mu.Lock()
+	if s.timer != cancel {
+		// Sync canceled this timer after it fired.
+		s.mu.Unlock()
+		return
+	}
+	s.timer = nil
+	s.nextSweep = time.Time{}
+	summaries := s.sweepLocked(now)
+	var next time.Time
+	for _, e := range s.entries {
+		if e.count > 0 && (next.IsZero() || e.expires.Before(next)) {
+			next = e.expires
+		}
+	}
+	if !next.IsZero() {
+		s.scheduleLocked(next)
+	}
+	s.mu.Unlock()
+
+	// Nothing waits on the timer, so there's nobody to report errors to.
+	_ = s.writeSummaries(summaries, now)
+}
+
+// sweepLocked removes entries whose windows ended, returning those with
+// suppressed entries to summarize. It looks at most once per window.
+func (s *dedupState) sweepLocked(now time.Time) []dedupEntry {
+	if now.Before(s.nextSweep) {
+		return nil
+	}
+	s.nextSweep = now.Add(s.window)
+
+	var summaries []dedupEntry
+	for key, e := range s.entries {
+		if now.Before(e.expires) {
+			continue
+		}
+		if e.count > 0 {
+			summaries = append(summaries, *e)
+		}
+		delete(s.entries, key)
+	}
+	return summaries
+}
+
+func (s *dedupState) writeSummaries(summaries []dedupEntry, now time.Time) error {
+	var err error
+	for _, e := range summaries {
+		ent := e.ent
+		ent.Time = now
+		ent.Message = fmt.Sprintf("message repeated %d times", e.count)
+		ent.Stack = ""
+		ent.StackFrames = nil
+
+		fields := make([]Field, 0, len(e.fields)+4)
+		fields = append(fields,
+			Field{Key: "repeatedMessage", Type: StringType, String: e.ent.Message},
+			Field{Key: "count", Type: Int64Type, Integer: int64(e.count)},
+			Field{Key: "firstSeen", Type: TimeFullType, Interface: e.firstSeen},
+			Field{Key: "lastSeen", Type: TimeFullType, Interface: e.lastSeen},
+		)
+		fields = append(fields, e.fields...)
+		err = multierr.Append(err, checkAndWrite(e.core, ent, fields))
+	}
+	return err
+}
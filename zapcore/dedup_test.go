@@ -0,0 +1,237 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap/internal/ztest"
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newDedupCore(t *testing.T, opts ...DedupOption) (Core, *ztest.MockClock, *observer.ObservedLogs) {
+	t.Helper()
+	clock := ztest.NewMockClock()
+	core, logs := observer.New(DebugLevel)
+	dedup := NewDedupCore(core, time.Minute, append([]DedupOption{DedupClock(clock)}, opts...)...)
+	// Sync stops any pending summary timer.
+	t.Cleanup(func() { assert.NoError(t, dedup.Sync(), "Unexpected error syncing.") })
+	return dedup, clock, logs
+}
+
+// waitForLogs waits for the timer to write summaries.
+func waitForLogs(t *testing.T, logs *observer.ObservedLogs, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool { return logs.Len() >= n }, time.Second, time.Millisecond,
+		"Expected %d entries.", n)
+}
+
+func writeDedup(t *testing.T, core Core, ent Entry, fields ...Field) {
+	t.Helper()
+	if ce := core.Check(ent, nil); ce != nil {
+		ce.Write(fields...)
+	}
+}
+
+func TestDedupCore(t *testing.T) {
+	core, clock, logs := newDedupCore(t)
+	start := clock.Now()
+	ent := Entry{Level: ErrorLevel, Message: "connection refused"}
+
+	writeDedup(t, core, ent)
+	for i := 0; i < 5; i++ {
+		clock.Add(time.Second)
+		writeDedup(t, core, ent)
+	}
+	writeDedup(t, core, Entry{Level: WarnLevel, Message: "connection refused"})
+	writeDedup(t, core, Entry{Level: ErrorLevel, Message: "connection reset"})
+	assert.Equal(t, 3, logs.Len(), "Expected repeats to be suppressed.")
+
+	clock.Add(time.Minute)
+	waitForLogs(t, logs, 4)
+	writeDedup(t, core, ent)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 5, "Expected a summary and a new first entry.")
+	summary := entries[3]
+	assert.Equal(t, ErrorLevel, summary.Level, "Unexpected summary level.")
+	assert.Equal(t, "message repeated 5 times", summary.Message, "Unexpected summary message.")
+	assert.Equal(t, map[string]interface{}{
+		"repeatedMessage": "connection refused",
+		"count":           int64(5),
+		"firstSeen":       start.Add(time.Second),
+		"lastSeen":        start.Add(5 * time.Second),
+	}, summary.ContextMap(), "Unexpected summary fields.")
+	assert.Equal(t, "connection refused", entries[4].Message, "Expected a new window to start.")
+}
+
+func TestDedupCoreCaller(t *testing.T) {
+	core, _, logs := newDedupCore(t)
+	at := func(line int) Entry {
+		return Entry{Message: "msg", Caller: EntryCaller{Defined: true, File: "foo.go", Line: line}}
+	}
+
+	writeDedup(t, core, at(1))
+	writeDedup(t, core, at(1))
+	writeDedup(t, core, at(2))
+	assert.Equal(t, 2, logs.Len(), "Expected entries from different callers to be distinct.")
+}
+
+func TestDedupCoreCompareFields(t *testing.T) {
+	ent := Entry{Message: "msg"}
+	field := func(v string) Field { return Field{Key: "k", Type: StringType, String: v} }
+
+	t.Run("ignored by default", func(t *testing.T) {
+		core, _, logs := newDedupCore(t)
+		writeDedup(t, core, ent, field("a"))
+		writeDedup(t, core, ent, field("b"))
+		assert.Equal(t, 1, logs.Len(), "Expected fields to be ignored.")
+	})
+
+	t.Run("compared", func(t *testing.T) {
+		core, _, logs := newDedupCore(t, DedupCompareFields())
+		writeDedup(t, core, ent, field("a"))
+		writeDedup(t, core, ent, field("b"))
+		writeDedup(t, core, ent, field("a"))
+		require.NoError(t, core.Sync(), "Unexpected error syncing.")
+
+		entries := logs.AllUntimed()
+		require.Len(t, entries, 3, "Expected distinct field values to be written.")
+		assert.Equal(t, "message repeated 1 times", entries[2].Message, "Unexpected summary message.")
+		assert.Equal(t, "a", entries[2].ContextMap()["k"], "Expected summary to carry the compared fields.")
+	})
+}
+
+func TestDedupCoreSync(t *testing.T) {
+	core, clock, logs := newDedupCore(t)
+	ent := Entry{Message: "msg"}
+
+	writeDedup(t, core, ent)
+	writeDedup(t, core, ent)
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	require.Equal(t, 2, logs.Len(), "Expected Sync to write a summary.")
+	assert.Equal(t, "message repeated 1 times", logs.All()[1].Message, "Unexpected summary message.")
+
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	writeDedup(t, core, ent)
+	assert.Equal(t, 2, logs.Len(), "Expected repeats to be suppressed until the window ends.")
+
+	clock.Add(time.Minute)
+	waitForLogs(t, logs, 3)
+	writeDedup(t, core, Entry{Message: "other"})
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 4, "Expected expired windows to be summarized.")
+	assert.Equal(t, "message repeated 1 times", entries[2].Message, "Unexpected summary message.")
+	assert.Equal(t, "other", entries[3].Message, "Unexpected message.")
+}
+
+func TestDedupCoreWith(t *testing.T) {
+	core, _, logs := newDedupCore(t)
+	child := core.With([]Field{{Key: "child", Type: BoolType, Integer: 1}})
+	sibling := core.With([]Field{{Key: "child", Type: BoolType, Integer: 0}})
+	ent := Entry{Message: "msg"}
+
+	writeDedup(t, child, ent)
+	writeDedup(t, sibling, ent)
+	writeDedup(t, core, ent)
+	assert.Equal(t, 3, logs.Len(), "Expected entries with different contexts to be distinct.")
+
+	writeDedup(t, core.With([]Field{{Key: "child", Type: BoolType, Integer: 1}}), ent)
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 4, "Expected derived cores with the same context to share state.")
+	assert.Equal(t, "message repeated 1 times", entries[3].Message, "Unexpected summary message.")
+	assert.Equal(t, true, entries[3].ContextMap()["child"], "Expected summary to use the entry's context.")
+}
+
+func TestDedupCoreTimer(t *testing.T) {
+	core, clock, logs := newDedupCore(t)
+	ent := Entry{Message: "msg"}
+
+	writeDedup(t, core, ent)
+	clock.Add(30 * time.Second)
+	writeDedup(t, core, ent)
+	writeDedup(t, core, Entry{Message: "other"})
+	clock.Add(10 * time.Second)
+	writeDedup(t, core, Entry{Message: "other"})
+	require.Equal(t, 2, logs.Len(), "Expected repeats to be suppressed.")
+
+	clock.Add(20 * time.Second)
+	waitForLogs(t, logs, 3)
+	assert.Equal(t, "msg", logs.AllUntimed()[2].ContextMap()["repeatedMessage"],
+		"Expected a summary once the first window ended, without further writes.")
+
+	clock.Add(30 * time.Second)
+	waitForLogs(t, logs, 4)
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 4, "Expected one summary per window.")
+	assert.Equal(t, "other", entries[3].ContextMap()["repeatedMessage"], "Expected the timer to reschedule itself.")
+}
+
+func TestDedupCoreLevel(t *testing.T) {
+	core, logs := observer.New(WarnLevel)
+	dedup := NewDedupCore(core, time.Minute)
+	assert.Equal(t, WarnLevel, LevelOf(dedup), "Unexpected level.")
+	writeDedup(t, dedup, Entry{Level: InfoLevel, Message: "msg"})
+	assert.Zero(t, logs.Len(), "Expected disabled levels to be dropped.")
+}
+
+func TestDedupCoreChecksWrappedCore(t *testing.T) {
+	core, logs := observer.New(DebugLevel)
+	dedup := NewDedupCore(NewSamplerWithOptions(core, time.Minute, 1, 0), time.Minute)
+	t.Cleanup(func() { assert.NoError(t, dedup.Sync(), "Unexpected error syncing.") })
+
+	// Entries from different callers are distinct to the dedup Core, but not
+	// to the sampler.
+	for line := 1; line <= 5; line++ {
+		writeDedup(t, dedup, Entry{Message: "msg", Time: time.Now(), Caller: EntryCaller{Defined: true, File: "foo.go", Line: line}})
+	}
+	assert.Equal(t, 1, logs.Len(), "Expected the wrapped sampler to drop repeats.")
+}
+
+func TestDedupCoreWriteErrors(t *testing.T) {
+	clock := ztest.NewMockClock()
+	core := NewDedupCore(&failingCore{err: errors.New("fail")}, time.Minute, DedupClock(clock))
+	ent := Entry{Message: "msg"}
+
+	assert.Error(t, core.Write(ent, nil), "Expected write errors to propagate.")
+	assert.NoError(t, core.Write(ent, nil), "Expected suppressed entries not to be written.")
+	assert.Error(t, core.Sync(), "Expected summary write errors to propagate.")
+}
+
+// failingCore is a Core whose writes always fail.
+type failingCore struct {
+	err error
+}
+
+func (c *failingCore) Enabled(Level) bool                              { return true }
+func (c *failingCore) With([]Field) Core                               { return c }
+func (c *failingCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry { return ce.AddCore(ent, c) }
+func (c *failingCore) Write(Entry, []Field) error                      { return c.err }
+func (c *failingCore) Sync() error                                     { return nil }
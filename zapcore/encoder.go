@@ -23,6 +23,7 @@ package zapcore
 import (
 	"encoding/json"
 	"io"
+	"runtime"
 	"time"
 
 	"go.uber.org/zap/buffer"
@@ -326,6 +327,70 @@ func (e *NameEncoder) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// A StacktraceEncoder serializes a stack trace to a primitive type or an
+// array. It receives the stack trace formatted as text like the stack
+// traces taken by zap.Stack, and the frames it was formatted from, if
+// known.
+//
+// This function must make exactly one call
+// to an ArrayEncoder's Append* method.
+type StacktraceEncoder func(stack string, frames StackFrames, enc ArrayEncoder)
+
+// StringStacktraceEncoder serializes a stack trace as-is.
+func StringStacktraceEncoder(stack string, _ StackFrames, enc ArrayEncoder) {
+	enc.AppendString(stack)
+}
+
+// FramesStacktraceEncoder serializes a stack trace as an array of frames,
+// each an object with "function", "file", and "line" keys, so that log
+// backends can index frames without parsing the text. Stack traces whose
+// frames aren't known, such as those set on an Entry by hand, are
+// serialized as strings.
+func FramesStacktraceEncoder(stack string, frames StackFrames, enc ArrayEncoder) {
+	if frames == nil {
+		enc.AppendString(stack)
+		return
+	}
+	_ = enc.AppendArray(frames) // StackFrames never fails
+}
+
+// UnmarshalText unmarshals text to a StacktraceEncoder. "frames" is
+// unmarshaled to FramesStacktraceEncoder and anything else is unmarshaled to
+// StringStacktraceEncoder.
+func (e *StacktraceEncoder) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "frames":
+		*e = FramesStacktraceEncoder
+	default:
+		*e = StringStacktraceEncoder
+	}
+	return nil
+}
+
+// StackFrames is an ArrayMarshaler for a stack trace. Each frame is encoded
+// as an object with "function", "file", and "line" keys, as by
+// FramesStacktraceEncoder.
+type StackFrames []runtime.Frame
+
+// MarshalLogArray implements ArrayMarshaler.
+func (fs StackFrames) MarshalLogArray(enc ArrayEncoder) error {
+	for i := range fs {
+		if err := enc.AppendObject(stackFrame(fs[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type stackFrame runtime.Frame
+
+func (f stackFrame) MarshalLogObject(enc ObjectEncoder) error {
+	enc.AddString("function", f.Function)
+	enc.AddString("file", f.File)
+	enc.AddInt("line", f.Line)
+	return nil
+}
+
 // An EncoderConfig allows users to configure the concrete encoders supplied by
 // zapcore.
 type EncoderConfig struct {
@@ -350,6 +415,10 @@ type EncoderConfig struct {
 	// Unlike the other primitive type encoders, EncodeName is optional. The
 	// zero value falls back to FullNameEncoder.
 	EncodeName NameEncoder `json:"nameEncoder" yaml:"nameEncoder"`
+	// EncodeStacktrace is optional too, and the zero value falls back to
+	// StringStacktraceEncoder. The console encoder always writes stack
+	// traces as text, and the logfmt encoder only supports strings.
+	EncodeStacktrace StacktraceEncoder `json:"stacktraceEncoder" yaml:"stacktraceEncoder"`
 	// Configure the encoder for interface{} type objects.
 	// If not provided, objects are encoded using json.Encoder
 	NewReflectedEncoder func(io.Writer) ReflectedEncoder `json:"-" yaml:"-"`
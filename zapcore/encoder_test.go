@@ -268,6 +268,54 @@ func TestEncoderConfiguration(t *testing.T) {
 			expectedJSON:    `{"L":"info","T":0,"N":"main","C":"foo.go:42","F":"foo.Foo","M":"hello"}` + "\n",
 			expectedConsole: "0\tinfo\tmain\tfoo.go:42\tfoo.Foo\thello\n",
 		},
+		{
+			desc: "use the supplied EncodeStacktrace in JSON output and ignore it in console output",
+			cfg: EncoderConfig{
+				LevelKey:         "L",
+				TimeKey:          "T",
+				MessageKey:       "M",
+				NameKey:          "N",
+				CallerKey:        "C",
+				StacktraceKey:    "S",
+				LineEnding:       base.LineEnding,
+				EncodeTime:       base.EncodeTime,
+				EncodeDuration:   base.EncodeDuration,
+				EncodeLevel:      base.EncodeLevel,
+				EncodeCaller:     base.EncodeCaller,
+				EncodeStacktrace: FramesStacktraceEncoder,
+			},
+			amendEntry: func(ent Entry) Entry {
+				ent.Stack = "foo.Foo\n\t/src/foo.go:42\nmain.main\n\tC:/src/main.go:7"
+				ent.StackFrames = StackFrames{
+					{Function: "foo.Foo", File: "/src/foo.go", Line: 42},
+					{Function: "main.main", File: "C:/src/main.go", Line: 7},
+				}
+				return ent
+			},
+			expectedJSON: `{"L":"info","T":0,"N":"main","C":"foo.go:42","M":"hello","S":[` +
+				`{"function":"foo.Foo","file":"/src/foo.go","line":42},` +
+				`{"function":"main.main","file":"C:/src/main.go","line":7}]}` + "\n",
+			expectedConsole: "0\tinfo\tmain\tfoo.go:42\thello\nfoo.Foo\n\t/src/foo.go:42\nmain.main\n\tC:/src/main.go:7\n",
+		},
+		{
+			desc: "handle no-op EncodeStacktrace",
+			cfg: EncoderConfig{
+				LevelKey:         "L",
+				TimeKey:          "T",
+				MessageKey:       "M",
+				NameKey:          "N",
+				CallerKey:        "C",
+				StacktraceKey:    "S",
+				LineEnding:       base.LineEnding,
+				EncodeTime:       base.EncodeTime,
+				EncodeDuration:   base.EncodeDuration,
+				EncodeLevel:      base.EncodeLevel,
+				EncodeCaller:     base.EncodeCaller,
+				EncodeStacktrace: func(string, StackFrames, ArrayEncoder) {},
+			},
+			expectedJSON:    `{"L":"info","T":0,"N":"main","C":"foo.go:42","M":"hello","S":"fake-stack"}` + "\n",
+			expectedConsole: "0\tinfo\tmain\tfoo.go:42\thello\nfake-stack\n",
+		},
 		{
 			desc: "use the supplied EncodeTime, for both the entry and any times added",
 			cfg: EncoderConfig{
@@ -699,6 +747,57 @@ func TestCallerEncoders(t *testing.T) {
 	}
 }
 
+func TestStacktraceEncoders(t *testing.T) {
+	const stack = "foo.Foo\n\t/src/foo.go:42\nmain.main\n\t/src/main.go:7"
+	stackFrames := StackFrames{
+		{Function: "foo.Foo", File: "/src/foo.go", Line: 42},
+		{Function: "main.main", File: "/src/main.go", Line: 7},
+	}
+	frames := []interface{}{
+		map[string]interface{}{"function": "foo.Foo", "file": "/src/foo.go", "line": 42},
+		map[string]interface{}{"function": "main.main", "file": "/src/main.go", "line": 7},
+	}
+	tests := []struct {
+		name     string
+		expected interface{} // output of serializing stack
+	}{
+		{"", stack},
+		{"something-random", stack},
+		{"string", stack},
+		{"frames", frames},
+	}
+
+	for _, tt := range tests {
+		var se StacktraceEncoder
+		require.NoError(t, se.UnmarshalText([]byte(tt.name)), "Unexpected error unmarshaling %q.", tt.name)
+		assertAppended(
+			t,
+			tt.expected,
+			func(arr ArrayEncoder) { se(stack, stackFrames, arr) },
+			"Unexpected output serializing stack trace with %q.", tt.name,
+		)
+	}
+}
+
+func TestFramesStacktraceEncoderWithoutFrames(t *testing.T) {
+	const stack = "foo.Foo\n\t/src/foo.go:42"
+	assertAppended(
+		t,
+		stack,
+		func(arr ArrayEncoder) { FramesStacktraceEncoder(stack, nil, arr) },
+		"Expected a stack trace without frames to be serialized as a string.",
+	)
+}
+
+func TestStackFrames(t *testing.T) {
+	enc := NewMapObjectEncoder()
+	frames := StackFrames{{Function: "foo.Foo", File: "/src/foo.go", Line: 42}}
+	require.NoError(t, enc.AddArray("stack", frames), "Unexpected error marshaling frames.")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"function": "foo.Foo", "file": "/src/foo.go", "line": 42},
+	}, enc.Fields["stack"], "Unexpected frames.")
+}
+
 func TestNameEncoders(t *testing.T) {
 	tests := []struct {
 		name     string
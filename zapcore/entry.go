@@ -147,6 +147,9 @@ type Entry struct {
 	Message    string
 	Caller     EntryCaller
 	Stack      string
+	// StackFrames are the frames that Stack was formatted from, if known.
+	// StacktraceEncoders use them to encode frames without parsing Stack.
+	StackFrames StackFrames
 }
 
 // CheckWriteHook is a custom action that may be executed after an entry is
@@ -292,6 +295,36 @@ func (ce *CheckedEntry) Write(fields ...Field) {
 	putCheckedEntry(ce)
 }
 
+// checkAndWrite runs core's Check for ent and writes to the Cores it adds,
+// returning their errors. Cores that decide in Write whether to log an
+// entry use it so that the filtering done by the wrapped Core's Check, such
+// as a Tee's per-Core levels, still applies.
+func checkAndWrite(core Core, ent Entry, fields []Field) error {
+	ce := core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+	defer putCheckedEntry(ce)
+
+	ent = ce.Entry
+	for i := range ce.before {
+		ent, fields = ce.before[i](ent, fields)
+	}
+	var err error
+	for i := range ce.cores {
+		err = multierr.Append(err, ce.cores[i].Write(ent, fields))
+	}
+	return err
+}
+
+// writeFromCheck writes an entry that a Core generates while checking
+// another one, such as a report of dropped entries. Check can't return
+// errors, and the Core's ErrorOutput is out of reach, so there's nowhere to
+// report a failure to write.
+func writeFromCheck(core Core, ent Entry, fields []Field) {
+	_ = core.Write(ent, fields)
+}
+
 // AddCore adds a Core that has agreed to log this CheckedEntry. It's intended to be
 // used by Core.Check implementations, and is safe to call on nil CheckedEntry
 // references.
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"strconv"
 	"time"
 )
 
@@ -231,3 +232,24 @@ func encodeStringer(key string, stringer interface{}, enc ObjectEncoder) (retErr
 	enc.AddString(key, stringer.(fmt.Stringer).String())
 	return nil
 }
+
+// fieldValueString formats the values of fields of simple types, for
+// comparisons.
+func fieldValueString(f Field) (string, bool) {
+	switch f.Type {
+	case StringType:
+		return f.String, true
+	case ByteStringType:
+		return string(f.Interface.([]byte)), true
+	case BoolType:
+		return strconv.FormatBool(f.Integer == 1), true
+	case Int64Type, Int32Type, Int16Type, Int8Type:
+		return strconv.FormatInt(f.Integer, 10), true
+	case Uint64Type, Uint32Type, Uint16Type, Uint8Type, UintptrType:
+		return strconv.FormatUint(uint64(f.Integer), 10), true
+	case DurationType:
+		return time.Duration(f.Integer).String(), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"sync"
+
+	"go.uber.org/multierr"
+)
+
+const _defaultFingersCrossedBufferSize = 1024
+
+// FingersCrossedOption configures a Core created with NewFingersCrossedCore.
+type FingersCrossedOption interface {
+	apply(*FingersCrossedCore)
+}
+
+type fingersCrossedOptionFunc func(*FingersCrossedCore)
+
+func (f fingersCrossedOptionFunc) apply(c *FingersCrossedCore) {
+	f(c)
+}
+
+// FingersCrossedBufferSize sets the number of entries each scope holds.
+// Once a scope holds this many, each new entry replaces the oldest one. It
+// defaults to 1024.
+func FingersCrossedBufferSize(n int) FingersCrossedOption {
+	return fingersCrossedOptionFunc(func(c *FingersCrossedCore) {
+		if n > 0 {
+			c.bufferSize = n
+		}
+	})
+}
+
+// FingersCrossedCore holds the entries logged in a scope, such as a
+// request, in memory until one of them reaches a trigger level. It then
+// writes everything it held, and every later entry in the scope, to the
+// wrapped Core. If no entry reaches the trigger level, the held entries are
+// dropped when the scope ends. This gives full debug context for failed
+// requests without paying to store the logs of the ones that succeed.
+//
+// Start a scope by calling Scope. Calling With within a scope adds fields
+// to that scope, while calling it outside a scope only adds fields, so
+// long-lived loggers built with With pass their entries through. Entries
+// written outside a scope are passed through. End a scope by calling
+// Discard on any Core in it:
+//
+//	fc := zapcore.NewFingersCrossedCore(core, zapcore.ErrorLevel)
+//
+//	func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+//		scope := fc.Scope([]zapcore.Field{
+//			{Key: "path", Type: zapcore.StringType, String: r.URL.Path},
+//		})
+//		defer scope.Discard()
+//		h.serve(w, r, scope)
+//	}
+//
+// where h.serve logs through a logger built on scope, such as one created
+// by New in go.uber.org/zap.
+//
+// The wrapped Core decides which levels are enabled, so it must enable the
+// levels that should be held.
+type FingersCrossedCore struct {
+	Core
+
+	trigger    LevelEnabler
+	bufferSize int
+	scope      *fingersCrossedScope // nil outside a scope
+}
+
+var (
+	_ Core           = (*FingersCrossedCore)(nil)
+	_ leveledEnabler = (*FingersCrossedCore)(nil)
+)
+
+// NewFingersCrossedCore wraps a Core so that, within each scope, entries
+// are held until one is enabled by trigger.
+func NewFingersCrossedCore(core Core, trigger LevelEnabler, opts ...FingersCrossedOption) *FingersCrossedCore {
+	c := &FingersCrossedCore{
+		Core:       core,
+		trigger:    trigger,
+		bufferSize: _defaultFingersCrossedBufferSize,
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+// fingersCrossedScope is shared by the Cores in a scope.
+type fingersCrossedScope struct {
+	mu        sync.Mutex
+	triggered bool
+	held      []heldEntry // a ring buffer once full
+	start     int         // index of the oldest entry in held
+}
+
+// heldEntry is an entry waiting for its scope to be triggered.
+type heldEntry struct {
+	core   Core // the Core to write the entry to
+	ent    Entry
+	fields []Field
+}
+
+// Level returns the minimum enabled level of the wrapped Core.
+func (c *FingersCrossedCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+// With adds fields to the wrapped Core. The returned Core is a
+// *FingersCrossedCore in the same scope as c, if any.
+func (c *FingersCrossedCore) With(fields []Field) Core {
+	clone := *c
+	clone.Core = c.Core.With(fields)
+	return &clone
+}
+
+// Scope starts a new scope, adding fields to the wrapped Core. Entries
+// written to the returned Core, and to Cores derived from it with With,
+// are held until the scope is triggered.
+func (c *FingersCrossedCore) Scope(fields []Field) *FingersCrossedCore {
+	clone := *c
+	if len(fields) > 0 {
+		clone.Core = c.Core.With(fields)
+	}
+	clone.scope = &fingersCrossedScope{}
+	return &clone
+}
+
+// Check adds the Core to the CheckedEntry if the wrapped Core enables the
+// entry's level.
+func (c *FingersCrossedCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write holds the entry if it's in a scope that hasn't been triggered and
+// its level is below the trigger level. Otherwise, it writes any held
+// entries and then this one, each through the wrapped Core's Check.
+func (c *FingersCrossedCore) Write(ent Entry, fields []Field) error {
+	s := c.scope
+	if s == nil {
+		return checkAndWrite(c.Core, ent, fields)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.triggered && !c.trigger.Enabled(ent.Level) {
+		// The caller may reuse fields after Write returns.
+		s.holdLocked(heldEntry{
+			core:   c.Core,
+			ent:    ent,
+			fields: append([]Field(nil), fields...),
+		}, c.bufferSize)
+		return nil
+	}
+
+	// Write while holding the lock so that held entries come first.
+	err := s.triggerLocked()
+	return multierr.Append(err, checkAndWrite(c.Core, ent, fields))
+}
+
+// Flush writes the entries held in the scope, and makes later entries in
+// the scope be written as they're logged, as if an entry at the trigger
+// level had been logged. Use it when a scope fails without logging an
+// error. Outside a scope, Flush does nothing.
+func (c *FingersCrossedCore) Flush() error {
+	s := c.scope
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.triggerLocked()
+}
+
+// Discard drops the entries held in the scope. Call it when the scope ends.
+// Later entries in the scope are held again, unless the scope was already
+// triggered. Outside a scope, Discard does nothing.
+func (c *FingersCrossedCore) Discard() {
+	s := c.scope
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.held = nil
+	s.start = 0
+}
+
+// Sync syncs the wrapped Core. It doesn't write held entries.
+func (c *FingersCrossedCore) Sync() error {
+	return c.Core.Sync()
+}
+
+func (s *fingersCrossedScope) holdLocked(e heldEntry, size int) {
+	if len(s.held) < size {
+		s.held = append(s.held, e)
+		return
+	}
+	s.held[s.start] = e
+	s.start = (s.start + 1) % len(s.held)
+}
+
+// triggerLocked writes the held entries, oldest first, and marks the scope
+// as triggered.
+func (s *fingersCrossedScope) triggerLocked() error {
+	var err error
+	for i := range s.held {
+		e := s.held[(s.start+i)%len(s.held)]
+		err = multierr.Append(err, checkAndWrite(e.core, e.ent, e.fields))
+	}
+	s.triggered = true
+	s.held = nil
+	s.start = 0
+	return err
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestFingersCrossedCore(t *testing.T) {
+	inner, logs := observer.New(DebugLevel)
+	core := NewFingersCrossedCore(inner, ErrorLevel)
+	assert.Equal(t, DebugLevel, core.Level(), "Unexpected level.")
+
+	writeEntry(core, DebugLevel, "outside")
+	assert.Equal(t, []string{"outside"}, messages(logs), "Expected entries outside a scope to be written.")
+	logs.TakeAll()
+
+	failed := core.Scope([]Field{{Key: "request", Type: StringType, String: "failed"}})
+	succeeded := core.Scope([]Field{{Key: "request", Type: StringType, String: "succeeded"}})
+
+	writeEntry(failed, DebugLevel, "parsing")
+	writeEntry(succeeded, DebugLevel, "parsing")
+	nested, ok := failed.With([]Field{{Key: "step", Type: StringType, String: "query"}}).(*FingersCrossedCore)
+	require.True(t, ok, "Expected With to return a *FingersCrossedCore.")
+	writeEntry(nested, InfoLevel, "querying")
+	writeEntry(succeeded, WarnLevel, "slow")
+	assert.Zero(t, logs.Len(), "Expected entries in scopes to be held.")
+
+	writeEntry(nested, ErrorLevel, "query failed")
+	writeEntry(failed, DebugLevel, "cleaning up")
+	assert.Equal(t, []string{"parsing", "querying", "query failed", "cleaning up"}, messages(logs),
+		"Expected the scope's held and later entries to be written once triggered.")
+	assert.Equal(t, map[string]interface{}{"request": "failed", "step": "query"}, logs.AllUntimed()[1].ContextMap(),
+		"Expected held entries to keep their context.")
+
+	succeeded.Discard()
+	writeEntry(succeeded, ErrorLevel, "late failure")
+	assert.Equal(t, "late failure", logs.AllUntimed()[logs.Len()-1].Message, "Expected discarded entries not to be written.")
+	assert.Equal(t, 5, logs.Len(), "Expected discarded entries not to be written.")
+}
+
+func TestFingersCrossedCoreBufferSize(t *testing.T) {
+	inner, logs := observer.New(DebugLevel)
+	scope := NewFingersCrossedCore(inner, ErrorLevel, FingersCrossedBufferSize(2)).Scope(nil)
+
+	for _, msg := range []string{"one", "two", "three", "four", "five"} {
+		writeEntry(scope, InfoLevel, msg)
+	}
+	require.NoError(t, scope.Flush(), "Unexpected error flushing.")
+	assert.Equal(t, []string{"four", "five"}, messages(logs), "Expected only the newest entries to be held.")
+
+	writeEntry(scope, InfoLevel, "six")
+	assert.Equal(t, []string{"four", "five", "six"}, messages(logs), "Expected Flush to trigger the scope.")
+}
+
+func TestFingersCrossedCoreLevels(t *testing.T) {
+	inner, logs := observer.New(InfoLevel)
+	scope := NewFingersCrossedCore(inner, WarnLevel).Scope(nil)
+
+	assert.False(t, scope.Enabled(DebugLevel), "Expected the wrapped Core to decide which levels are enabled.")
+	writeEntry(scope, DebugLevel, "disabled")
+	writeEntry(scope, InfoLevel, "held")
+	writeEntry(scope, WarnLevel, "trigger")
+	assert.Equal(t, []string{"held", "trigger"}, messages(logs), "Unexpected entries.")
+}
+
+func TestFingersCrossedCoreUnscoped(t *testing.T) {
+	inner, logs := observer.New(DebugLevel)
+	core := NewFingersCrossedCore(inner, ErrorLevel)
+
+	assert.NoError(t, core.Flush(), "Expected Flush outside a scope to do nothing.")
+	core.Discard()
+	assert.NoError(t, core.Sync(), "Unexpected error syncing.")
+	assert.Zero(t, logs.Len(), "Expected no entries.")
+
+	component := core.With([]Field{{Key: "component", Type: StringType, String: "db"}})
+	writeEntry(component, DebugLevel, "connected")
+	require.Equal(t, []string{"connected"}, messages(logs), "Expected With not to start a scope.")
+	assert.Equal(t, map[string]interface{}{"component": "db"}, logs.AllUntimed()[0].ContextMap(),
+		"Expected With to add fields.")
+}
+
+func TestFingersCrossedCoreChecksWrappedCore(t *testing.T) {
+	inner, logs := observer.New(DebugLevel)
+	core := NewFingersCrossedCore(NewSamplerWithOptions(inner, time.Minute, 1, 0), ErrorLevel)
+
+	for i := 0; i < 3; i++ {
+		writeEntry(core, InfoLevel, "outside")
+	}
+	assert.Equal(t, []string{"outside"}, messages(logs), "Expected the wrapped sampler to drop repeats outside a scope.")
+	logs.TakeAll()
+
+	scope := core.Scope(nil)
+	for i := 0; i < 3; i++ {
+		writeEntry(scope, DebugLevel, "held")
+	}
+	writeEntry(scope, ErrorLevel, "failed")
+	writeEntry(scope, ErrorLevel, "failed")
+	assert.Equal(t, []string{"held", "failed"}, messages(logs),
+		"Expected the wrapped sampler to drop repeats of held and triggering entries.")
+}
+
+func TestFingersCrossedCoreErrors(t *testing.T) {
+	scope := NewFingersCrossedCore(&failingCore{err: errors.New("fail")}, ErrorLevel).Scope(nil)
+
+	assert.NoError(t, scope.Write(Entry{Level: InfoLevel}, nil), "Expected held entries not to be written.")
+	assert.Error(t, scope.Write(Entry{Level: ErrorLevel}, nil), "Expected write errors to propagate.")
+}
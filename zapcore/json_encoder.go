@@ -420,7 +420,18 @@ func (enc *jsonEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer,
 	addFields(final, fields)
 	final.closeOpenNamespaces()
 	if ent.Stack != "" && final.StacktraceKey != "" {
-		final.AddString(final.StacktraceKey, ent.Stack)
+		if final.EncodeStacktrace == nil {
+			final.AddString(final.StacktraceKey, ent.Stack)
+		} else {
+			final.addKey(final.StacktraceKey)
+			cur := final.buf.Len()
+			final.EncodeStacktrace(ent.Stack, ent.StackFrames, final)
+			if cur == final.buf.Len() {
+				// User-supplied EncodeStacktrace was a no-op. Fall back to
+				// strings to keep output JSON valid.
+				final.AppendString(ent.Stack)
+			}
+		}
 	}
 	final.buf.AppendByte('}')
 	final.buf.AppendString(final.LineEnding)
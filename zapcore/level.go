@@ -227,3 +227,19 @@ func (l Level) Enabled(lvl Level) bool {
 type LevelEnabler interface {
 	Enabled(Level) bool
 }
+
+// NamedLevelEnabler is a LevelEnabler that can also decide whether a level
+// is enabled for a particular logger, identified by the name in
+// Entry.LoggerName.
+//
+// Its Enabled method must report whether the level is enabled for any
+// logger, so that callers can skip disabled entries cheaply before a name
+// is considered. Cores created by NewCore with a NamedLevelEnabler call
+// EnabledFor from their Check method.
+type NamedLevelEnabler interface {
+	LevelEnabler
+
+	// EnabledFor reports whether the given level is enabled for the logger
+	// with the given name.
+	EnabledFor(name string, lvl Level) bool
+}
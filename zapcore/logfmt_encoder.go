@@ -0,0 +1,487 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"encoding/base64"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/internal/bufferpool"
+	"go.uber.org/zap/internal/pool"
+)
+
+var _logfmtPool = pool.New(func() *logfmtEncoder {
+	return &logfmtEncoder{}
+})
+
+func putLogfmtEncoder(enc *logfmtEncoder) {
+	enc.EncoderConfig = nil
+	enc.buf = nil
+	enc.prefix = ""
+	_logfmtPool.Put(enc)
+}
+
+type logfmtEncoder struct {
+	*EncoderConfig
+	buf    *buffer.Buffer
+	prefix string // dotted path of open objects and namespaces, ending in "."
+}
+
+// NewLogfmtEncoder creates an encoder that writes each entry as a line of
+// space-separated key=value pairs, as understood by logfmt parsers:
+//
+//	level=info ts=1.7e+09 msg="request handled" http.status=200
+//
+// Keys of nested objects and namespaces are joined to their parent's key
+// with periods. Values are quoted if they're empty or contain spaces, quotes,
+// equals signs, or control characters, and quoted values are escaped like
+// JSON strings. Characters in keys that logfmt doesn't allow are replaced
+// with underscores.
+//
+// logfmt has no notation for arrays, so arrays and values encoded by
+// reflection are written as JSON and then quoted as needed.
+//
+// Like the JSON encoder, the logfmt encoder doesn't deduplicate keys.
+func NewLogfmtEncoder(cfg EncoderConfig) Encoder {
+	return newLogfmtEncoder(cfg)
+}
+
+func newLogfmtEncoder(cfg EncoderConfig) *logfmtEncoder {
+	if cfg.SkipLineEnding {
+		cfg.LineEnding = ""
+	} else if cfg.LineEnding == "" {
+		cfg.LineEnding = DefaultLineEnding
+	}
+
+	// If no EncoderConfig.NewReflectedEncoder is provided by the user, then use default
+	if cfg.NewReflectedEncoder == nil {
+		cfg.NewReflectedEncoder = defaultReflectedEncoder
+	}
+
+	return &logfmtEncoder{
+		EncoderConfig: &cfg,
+		buf:           bufferpool.Get(),
+	}
+}
+
+func (enc *logfmtEncoder) AddArray(key string, arr ArrayMarshaler) error {
+	enc.addKey(key)
+	return enc.AppendArray(arr)
+}
+
+func (enc *logfmtEncoder) AddObject(key string, obj ObjectMarshaler) error {
+	// Flatten the object into the current line, restoring the prefix
+	// afterwards to close any namespaces the object opened.
+	old := enc.prefix
+	enc.prefix = old + key + "."
+	err := obj.MarshalLogObject(enc)
+	enc.prefix = old
+	return err
+}
+
+func (enc *logfmtEncoder) AddBinary(key string, val []byte) {
+	enc.AddString(key, base64.StdEncoding.EncodeToString(val))
+}
+
+func (enc *logfmtEncoder) AddByteString(key string, val []byte) {
+	enc.addKey(key)
+	enc.AppendByteString(val)
+}
+
+func (enc *logfmtEncoder) AddBool(key string, val bool) {
+	enc.addKey(key)
+	enc.AppendBool(val)
+}
+
+func (enc *logfmtEncoder) AddComplex128(key string, val complex128) {
+	enc.addKey(key)
+	enc.AppendComplex128(val)
+}
+
+func (enc *logfmtEncoder) AddComplex64(key string, val complex64) {
+	enc.addKey(key)
+	enc.AppendComplex64(val)
+}
+
+func (enc *logfmtEncoder) AddDuration(key string, val time.Duration) {
+	enc.addKey(key)
+	enc.AppendDuration(val)
+}
+
+func (enc *logfmtEncoder) AddFloat64(key string, val float64) {
+	enc.addKey(key)
+	enc.AppendFloat64(val)
+}
+
+func (enc *logfmtEncoder) AddFloat32(key string, val float32) {
+	enc.addKey(key)
+	enc.AppendFloat32(val)
+}
+
+func (enc *logfmtEncoder) AddInt64(key string, val int64) {
+	enc.addKey(key)
+	enc.AppendInt64(val)
+}
+
+func (enc *logfmtEncoder) AddReflected(key string, obj interface{}) error {
+	enc.addKey(key)
+	return enc.AppendReflected(obj)
+}
+
+func (enc *logfmtEncoder) OpenNamespace(key string) {
+	enc.prefix += key + "."
+}
+
+func (enc *logfmtEncoder) AddString(key, val string) {
+	enc.addKey(key)
+	enc.AppendString(val)
+}
+
+func (enc *logfmtEncoder) AddTime(key string, val time.Time) {
+	enc.addKey(key)
+	enc.AppendTime(val)
+}
+
+func (enc *logfmtEncoder) AddUint64(key string, val uint64) {
+	enc.addKey(key)
+	enc.AppendUint64(val)
+}
+
+func (enc *logfmtEncoder) AppendArray(arr ArrayMarshaler) error {
+	return enc.appendJSON(func(json *jsonEncoder) error {
+		return json.AppendArray(arr)
+	})
+}
+
+func (enc *logfmtEncoder) AppendObject(obj ObjectMarshaler) error {
+	return enc.appendJSON(func(json *jsonEncoder) error {
+		return json.AppendObject(obj)
+	})
+}
+
+func (enc *logfmtEncoder) AppendBool(val bool) {
+	enc.addElementSeparator()
+	enc.buf.AppendBool(val)
+}
+
+func (enc *logfmtEncoder) AppendByteString(val []byte) {
+	enc.addElementSeparator()
+	appendLogfmtValue((*buffer.Buffer).AppendBytes, utf8.DecodeRune, enc.buf, val)
+}
+
+// appendComplex appends the encoded form of the provided complex128 value.
+// precision specifies the encoding precision for the real and imaginary
+// components of the complex number.
+func (enc *logfmtEncoder) appendComplex(val complex128, precision int) {
+	enc.addElementSeparator()
+	// Cast to a platform-independent, fixed-size type.
+	r, i := float64(real(val)), float64(imag(val))
+	enc.buf.AppendFloat(r, precision)
+	// If imaginary part is less than 0, minus (-) sign is added by default
+	// by AppendFloat.
+	if i >= 0 {
+		enc.buf.AppendByte('+')
+	}
+	enc.buf.AppendFloat(i, precision)
+	enc.buf.AppendByte('i')
+}
+
+func (enc *logfmtEncoder) AppendDuration(val time.Duration) {
+	cur := enc.buf.Len()
+	if e := enc.EncodeDuration; e != nil {
+		e(val, enc)
+	}
+	if cur == enc.buf.Len() {
+		// User-supplied EncodeDuration is a no-op. Fall back to nanoseconds to
+		// keep the key from dangling.
+		enc.AppendInt64(int64(val))
+	}
+}
+
+func (enc *logfmtEncoder) AppendInt64(val int64) {
+	enc.addElementSeparator()
+	enc.buf.AppendInt(val)
+}
+
+func (enc *logfmtEncoder) AppendReflected(val interface{}) error {
+	return enc.appendJSON(func(json *jsonEncoder) error {
+		return json.AppendReflected(val)
+	})
+}
+
+func (enc *logfmtEncoder) AppendString(val string) {
+	enc.addElementSeparator()
+	appendLogfmtValue((*buffer.Buffer).AppendString, utf8.DecodeRuneInString, enc.buf, val)
+}
+
+func (enc *logfmtEncoder) AppendTimeLayout(time time.Time, layout string) {
+	// Layouts may contain spaces, so format the time before deciding
+	// whether to quote it.
+	buf := bufferpool.Get()
+	buf.AppendTime(time, layout)
+	enc.AppendByteString(buf.Bytes())
+	buf.Free()
+}
+
+func (enc *logfmtEncoder) AppendTime(val time.Time) {
+	cur := enc.buf.Len()
+	if e := enc.EncodeTime; e != nil {
+		e(val, enc)
+	}
+	if cur == enc.buf.Len() {
+		// User-supplied EncodeTime is a no-op. Fall back to nanos since epoch
+		// to keep the key from dangling.
+		enc.AppendInt64(val.UnixNano())
+	}
+}
+
+func (enc *logfmtEncoder) AppendUint64(val uint64) {
+	enc.addElementSeparator()
+	enc.buf.AppendUint(val)
+}
+
+func (enc *logfmtEncoder) AddInt(k string, v int)         { enc.AddInt64(k, int64(v)) }
+func (enc *logfmtEncoder) AddInt32(k string, v int32)     { enc.AddInt64(k, int64(v)) }
+func (enc *logfmtEncoder) AddInt16(k string, v int16)     { enc.AddInt64(k, int64(v)) }
+func (enc *logfmtEncoder) AddInt8(k string, v int8)       { enc.AddInt64(k, int64(v)) }
+func (enc *logfmtEncoder) AddUint(k string, v uint)       { enc.AddUint64(k, uint64(v)) }
+func (enc *logfmtEncoder) AddUint32(k string, v uint32)   { enc.AddUint64(k, uint64(v)) }
+func (enc *logfmtEncoder) AddUint16(k string, v uint16)   { enc.AddUint64(k, uint64(v)) }
+func (enc *logfmtEncoder) AddUint8(k string, v uint8)     { enc.AddUint64(k, uint64(v)) }
+func (enc *logfmtEncoder) AddUintptr(k string, v uintptr) { enc.AddUint64(k, uint64(v)) }
+func (enc *logfmtEncoder) AppendComplex64(v complex64)    { enc.appendComplex(complex128(v), 32) }
+func (enc *logfmtEncoder) AppendComplex128(v complex128)  { enc.appendComplex(complex128(v), 64) }
+func (enc *logfmtEncoder) AppendFloat64(v float64)        { enc.appendFloat(v, 64) }
+func (enc *logfmtEncoder) AppendFloat32(v float32)        { enc.appendFloat(float64(v), 32) }
+func (enc *logfmtEncoder) AppendInt(v int)                { enc.AppendInt64(int64(v)) }
+func (enc *logfmtEncoder) AppendInt32(v int32)            { enc.AppendInt64(int64(v)) }
+func (enc *logfmtEncoder) AppendInt16(v int16)            { enc.AppendInt64(int64(v)) }
+func (enc *logfmtEncoder) AppendInt8(v int8)              { enc.AppendInt64(int64(v)) }
+func (enc *logfmtEncoder) AppendUint(v uint)              { enc.AppendUint64(uint64(v)) }
+func (enc *logfmtEncoder) AppendUint32(v uint32)          { enc.AppendUint64(uint64(v)) }
+func (enc *logfmtEncoder) AppendUint16(v uint16)          { enc.AppendUint64(uint64(v)) }
+func (enc *logfmtEncoder) AppendUint8(v uint8)            { enc.AppendUint64(uint64(v)) }
+func (enc *logfmtEncoder) AppendUintptr(v uintptr)        { enc.AppendUint64(uint64(v)) }
+
+func (enc *logfmtEncoder) Clone() Encoder {
+	clone := enc.clone()
+	clone.buf.Write(enc.buf.Bytes())
+	return clone
+}
+
+func (enc *logfmtEncoder) clone() *logfmtEncoder {
+	clone := _logfmtPool.Get()
+	clone.EncoderConfig = enc.EncoderConfig
+	clone.prefix = enc.prefix
+	clone.buf = bufferpool.Get()
+	return clone
+}
+
+func (enc *logfmtEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer, error) {
+	final := enc.clone()
+	// Entry metadata is never namespaced.
+	final.prefix = ""
+
+	if final.LevelKey != "" && final.EncodeLevel != nil {
+		final.addKey(final.LevelKey)
+		cur := final.buf.Len()
+		final.EncodeLevel(ent.Level, final)
+		if cur == final.buf.Len() {
+			// User-supplied EncodeLevel was a no-op. Fall back to strings to
+			// keep the key from dangling.
+			final.AppendString(ent.Level.String())
+		}
+	}
+	if final.TimeKey != "" && !ent.Time.IsZero() {
+		final.AddTime(final.TimeKey, ent.Time)
+	}
+	if ent.LoggerName != "" && final.NameKey != "" {
+		final.addKey(final.NameKey)
+		cur := final.buf.Len()
+		nameEncoder := final.EncodeName
+
+		// if no name encoder provided, fall back to FullNameEncoder for backwards
+		// compatibility
+		if nameEncoder == nil {
+			nameEncoder = FullNameEncoder
+		}
+
+		nameEncoder(ent.LoggerName, final)
+		if cur == final.buf.Len() {
+			// User-supplied EncodeName was a no-op. Fall back to strings to
+			// keep the key from dangling.
+			final.AppendString(ent.LoggerName)
+		}
+	}
+	if ent.Caller.Defined {
+		if final.CallerKey != "" {
+			final.addKey(final.CallerKey)
+			cur := final.buf.Len()
+			final.EncodeCaller(ent.Caller, final)
+			if cur == final.buf.Len() {
+				// User-supplied EncodeCaller was a no-op. Fall back to strings
+				// to keep the key from dangling.
+				final.AppendString(ent.Caller.String())
+			}
+		}
+		if final.FunctionKey != "" {
+			final.AddString(final.FunctionKey, ent.Caller.Function)
+		}
+	}
+	if final.MessageKey != "" {
+		final.AddString(final.MessageKey, ent.Message)
+	}
+	if enc.buf.Len() > 0 {
+		final.addElementSeparator()
+		final.buf.Write(enc.buf.Bytes())
+	}
+	final.prefix = enc.prefix
+	addFields(final, fields)
+	final.prefix = ""
+	if ent.Stack != "" && final.StacktraceKey != "" {
+		final.AddString(final.StacktraceKey, ent.Stack)
+	}
+	final.buf.AppendString(final.LineEnding)
+
+	ret := final.buf
+	putLogfmtEncoder(final)
+	return ret, nil
+}
+
+// addKey writes the key, qualified by any open objects and namespaces,
+// followed by an equals sign.
+func (enc *logfmtEncoder) addKey(key string) {
+	if enc.buf.Len() > 0 {
+		enc.buf.AppendByte(' ')
+	}
+	if enc.prefix == "" && key == "" {
+		// logfmt doesn't allow empty keys.
+		enc.buf.AppendByte('_')
+	} else {
+		appendLogfmtKey(enc.buf, enc.prefix)
+		appendLogfmtKey(enc.buf, key)
+	}
+	enc.buf.AppendByte('=')
+}
+
+// addElementSeparator separates values appended without a key, such as by
+// a custom EncodeLevel that appends more than one value.
+func (enc *logfmtEncoder) addElementSeparator() {
+	last := enc.buf.Len() - 1
+	if last < 0 {
+		return
+	}
+	if enc.buf.Bytes()[last] != '=' {
+		enc.buf.AppendByte(' ')
+	}
+}
+
+func (enc *logfmtEncoder) appendFloat(val float64, bitSize int) {
+	enc.addElementSeparator()
+	// strconv writes NaN, +Inf, and -Inf, none of which need quoting.
+	enc.buf.AppendFloat(val, bitSize)
+}
+
+// appendJSON appends a value that logfmt can't represent natively, such as
+// an array, by encoding it as JSON with fn and writing the result as a
+// single logfmt value.
+func (enc *logfmtEncoder) appendJSON(fn func(*jsonEncoder) error) error {
+	json := _jsonPool.Get()
+	json.EncoderConfig = enc.EncoderConfig
+	json.buf = bufferpool.Get()
+	defer func() {
+		json.buf.Free()
+		putJSONEncoder(json)
+	}()
+
+	err := fn(json)
+	enc.AppendByteString(json.buf.Bytes())
+	return err
+}
+
+// appendLogfmtKey appends s to buf, replacing characters that aren't allowed
+// in logfmt keys with underscores.
+func appendLogfmtKey(buf *buffer.Buffer, s string) {
+	for i := 0; i < len(s); {
+		r, size := rune(s[i]), 1
+		if r >= utf8.RuneSelf {
+			r, size = utf8.DecodeRuneInString(s[i:])
+		}
+		if isLogfmtSpecial(r, size) {
+			buf.AppendByte('_')
+		} else {
+			buf.AppendString(s[i : i+size])
+		}
+		i += size
+	}
+}
+
+// appendLogfmtValue appends a string-like value to buf, quoting and escaping
+// it if necessary.
+func appendLogfmtValue[S []byte | string](
+	appendTo func(*buffer.Buffer, S),
+	decodeRune func(S) (rune, int),
+	buf *buffer.Buffer,
+	s S,
+) {
+	if !needsLogfmtQuotes(decodeRune, s) {
+		appendTo(buf, s)
+		return
+	}
+	buf.AppendByte('"')
+	safeAppendStringLike(appendTo, decodeRune, buf, s)
+	buf.AppendByte('"')
+}
+
+// needsLogfmtQuotes reports whether s is empty or contains characters that
+// can't appear in an unquoted logfmt value.
+func needsLogfmtQuotes[S []byte | string](decodeRune func(S) (rune, int), s S) bool {
+	if len(s) == 0 {
+		return true
+	}
+	for i := 0; i < len(s); {
+		r, size := rune(s[i]), 1
+		if r >= utf8.RuneSelf {
+			r, size = decodeRune(s[i:])
+		}
+		if isLogfmtSpecial(r, size) {
+			return true
+		}
+		i += size
+	}
+	return false
+}
+
+// isLogfmtSpecial reports whether the rune r, decoded from size bytes, is a
+// space, control character, equals sign, quote, or invalid UTF-8.
+func isLogfmtSpecial(r rune, size int) bool {
+	switch {
+	case r <= ' ', r == '=', r == '"', r == utf8.RuneError && size == 1:
+		return true
+	case r < utf8.RuneSelf:
+		return r == 0x7f
+	default:
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	}
+}
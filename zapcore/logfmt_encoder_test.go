@@ -0,0 +1,248 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type logfmtUser struct {
+	Name  string
+	Email string
+}
+
+func (u logfmtUser) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", u.Name)
+	enc.OpenNamespace("contact")
+	enc.AddString("email", u.Email)
+	return nil
+}
+
+func TestLogfmtEncodeEntry(t *testing.T) {
+	tests := []struct {
+		desc     string
+		expected string
+		ent      zapcore.Entry
+		fields   []zapcore.Field
+	}{
+		{
+			desc:     "info entry with some fields",
+			expected: `L=info T=2018-06-19T16:33:42.000Z N=bob M="lob law" so=passes answer=42 pi=3.14 ok=true c=3.14-2.71i d=1.5`,
+			ent: zapcore.Entry{
+				Level:      zapcore.InfoLevel,
+				Time:       time.Date(2018, 6, 19, 16, 33, 42, 99, time.UTC),
+				LoggerName: "bob",
+				Message:    "lob law",
+			},
+			fields: []zapcore.Field{
+				zap.String("so", "passes"),
+				zap.Int("answer", 42),
+				zap.Float64("pi", 3.14),
+				zap.Bool("ok", true),
+				zap.Complex128("c", 3.14-2.71i),
+				zap.Duration("d", 1500*time.Millisecond),
+			},
+		},
+		{
+			desc:     "quoting and escaping",
+			expected: `L=info M=msg empty="" space="a b" eq="a=b" quote="say \"hi\"" newline="a\nb" tab="a\tb" nbsp="a` + "\u00a0" + `b" invalid="\ufffd" backslash=C:\dir unicode=héllo`,
+			ent:      zapcore.Entry{Message: "msg"},
+			fields: []zapcore.Field{
+				zap.String("empty", ""),
+				zap.String("space", "a b"),
+				zap.String("eq", "a=b"),
+				zap.String("quote", `say "hi"`),
+				zap.String("newline", "a\nb"),
+				zap.ByteString("tab", []byte("a\tb")),
+				zap.String("nbsp", "a\u00a0b"),
+				zap.String("invalid", "\xff"),
+				zap.String("backslash", `C:\dir`),
+				zap.String("unicode", "héllo"),
+			},
+		},
+		{
+			desc:     "invalid keys",
+			expected: `L=info M=msg a_b=1 a_b=2 a_b=3 _=4`,
+			ent:      zapcore.Entry{Message: "msg"},
+			fields: []zapcore.Field{
+				zap.Int("a b", 1),
+				zap.Int("a=b", 2),
+				zap.Int(`a"b`, 3),
+				zap.Int("", 4),
+			},
+		},
+		{
+			desc:     "nested objects and namespaces",
+			expected: `L=info M=msg user.name=alice user.contact.email=alice@example.com after=1 ns.inner=2 ns.deeper.innermost=3`,
+			ent:      zapcore.Entry{Message: "msg"},
+			fields: []zapcore.Field{
+				zap.Object("user", logfmtUser{Name: "alice", Email: "alice@example.com"}),
+				zap.Int("after", 1),
+				zap.Namespace("ns"),
+				zap.Int("inner", 2),
+				zap.Namespace("deeper"),
+				zap.Int("innermost", 3),
+			},
+		},
+		{
+			desc:     "arrays and reflection",
+			expected: `L=info M=msg ints=[1,2,3] strs="[\"a b\",\"c\"]" users="[{\"name\":\"bob\",\"contact\":{\"email\":\"b\"}}]" refl="{\"k\":\"v\"}" null=null`,
+			ent:      zapcore.Entry{Message: "msg"},
+			fields: []zapcore.Field{
+				zap.Ints("ints", []int{1, 2, 3}),
+				zap.Strings("strs", []string{"a b", "c"}),
+				zap.Objects("users", []logfmtUser{{Name: "bob", Email: "b"}}),
+				zap.Reflect("refl", map[string]string{"k": "v"}),
+				zap.Reflect("null", nil),
+			},
+		},
+		{
+			desc:     "special floats",
+			expected: `L=info M=msg nan=NaN inf=+Inf ninf=-Inf`,
+			ent:      zapcore.Entry{Message: "msg"},
+			fields: []zapcore.Field{
+				zap.Float64("nan", math.NaN()),
+				zap.Float64("inf", math.Inf(1)),
+				zap.Float32("ninf", float32(math.Inf(-1))),
+			},
+		},
+		{
+			desc:     "caller, function, and stacktrace",
+			expected: `L=error C=zap/foo.go:42 F=foo.Bar M=oops error=boom S="line 1\nline 2"`,
+			ent: zapcore.Entry{
+				Level:   zapcore.ErrorLevel,
+				Message: "oops",
+				Caller: zapcore.EntryCaller{
+					Defined:  true,
+					File:     "/src/go.uber.org/zap/foo.go",
+					Line:     42,
+					Function: "foo.Bar",
+				},
+				Stack: "line 1\nline 2",
+			},
+			fields: []zapcore.Field{zap.Error(errors.New("boom"))},
+		},
+	}
+
+	enc := zapcore.NewLogfmtEncoder(zapcore.EncoderConfig{
+		MessageKey:     "M",
+		LevelKey:       "L",
+		TimeKey:        "T",
+		NameKey:        "N",
+		CallerKey:      "C",
+		FunctionKey:    "F",
+		StacktraceKey:  "S",
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeDuration: zapcore.SecondsDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf, err := enc.EncodeEntry(tt.ent, tt.fields)
+			require.NoError(t, err, "Unexpected logfmt encoding error.")
+			assert.Equal(t, tt.expected+"\n", buf.String(), "Incorrect encoded logfmt entry.")
+			buf.Free()
+		})
+	}
+}
+
+func TestLogfmtEncoderWith(t *testing.T) {
+	enc := zapcore.NewLogfmtEncoder(zapcore.EncoderConfig{
+		MessageKey:    "msg",
+		LevelKey:      "level",
+		StacktraceKey: "stack",
+		EncodeLevel:   zapcore.LowercaseLevelEncoder,
+	})
+	zap.String("service", "api").AddTo(enc)
+	zap.Namespace("req").AddTo(enc)
+	zap.String("id", "42").AddTo(enc)
+	clone := enc.Clone()
+	zap.String("mutated", "after clone").AddTo(enc)
+
+	buf, err := clone.EncodeEntry(zapcore.Entry{Level: zapcore.WarnLevel, Message: "hi", Stack: "trace"}, []zapcore.Field{
+		zap.Int("status", 200),
+	})
+	require.NoError(t, err, "Unexpected logfmt encoding error.")
+	assert.Equal(t,
+		"level=warn msg=hi service=api req.id=42 req.status=200 stack=trace\n",
+		buf.String(),
+		"Expected context and namespaces to carry over to the entry.",
+	)
+	buf.Free()
+}
+
+func TestLogfmtEmptyConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		field    zapcore.Field
+		expected string
+	}{
+		{
+			name:     "time",
+			field:    zap.Time("foo", time.Unix(1591287718, 0)),
+			expected: "foo=1591287718000000000\n",
+		},
+		{
+			name:     "duration",
+			field:    zap.Duration("bar", time.Microsecond),
+			expected: "bar=1000\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := zapcore.NewLogfmtEncoder(zapcore.EncoderConfig{})
+
+			buf, err := enc.EncodeEntry(zapcore.Entry{
+				Level:      zapcore.DebugLevel,
+				Time:       time.Now(),
+				LoggerName: "mylogger",
+				Message:    "things happened",
+			}, []zapcore.Field{tt.field})
+			require.NoError(t, err, "Unexpected logfmt encoding error.")
+			assert.Equal(t, tt.expected, buf.String(), "Incorrect encoded logfmt entry.")
+			buf.Free()
+		})
+	}
+}
+
+func TestLogfmtTimeLayout(t *testing.T) {
+	enc := zapcore.NewLogfmtEncoder(zapcore.EncoderConfig{
+		TimeKey:        "ts",
+		EncodeTime:     zapcore.TimeEncoderOfLayout("2006-01-02 15:04:05"),
+		SkipLineEnding: true,
+	})
+	buf, err := enc.EncodeEntry(zapcore.Entry{Time: time.Date(2020, 6, 4, 9, 21, 58, 0, time.UTC)}, nil)
+	require.NoError(t, err, "Unexpected logfmt encoding error.")
+	assert.Equal(t, `ts="2020-06-04 09:21:58"`, buf.String(), "Expected layouts with spaces to be quoted.")
+	buf.Free()
+}
@@ -0,0 +1,309 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+const _defaultRateLimitMarkerInterval = time.Second
+
+// RateLimitOption configures a Core created with NewRateLimitedCore.
+type RateLimitOption interface {
+	apply(*rateLimiter)
+}
+
+type rateLimitOptionFunc func(*rateLimiter)
+
+func (f rateLimitOptionFunc) apply(l *rateLimiter) {
+	f(l)
+}
+
+// RateLimitClock sets the Clock used to refill buckets and to timestamp
+// markers. It defaults to DefaultClock.
+func RateLimitClock(clock Clock) RateLimitOption {
+	return rateLimitOptionFunc(func(l *rateLimiter) {
+		l.clock = clock
+	})
+}
+
+// RateLimitLevel overrides the rate and burst for entries at lvl. It has no
+// effect with RateLimitByLoggerName.
+func RateLimitLevel(lvl Level, rate float64, burst int) RateLimitOption {
+	return rateLimitOptionFunc(func(l *rateLimiter) {
+		l.levels[lvl] = rateLimit{rate: rate, burst: float64(burst)}
+	})
+}
+
+// RateLimitByLoggerName gives each logger name, as set by Logger.Named, its
+// own bucket, instead of each level.
+func RateLimitByLoggerName() RateLimitOption {
+	return rateLimitOptionFunc(func(l *rateLimiter) {
+		l.byName = true
+	})
+}
+
+// RateLimitMarkerInterval sets the minimum time between "rate limit
+// exceeded" markers for a bucket. It defaults to one second.
+func RateLimitMarkerInterval(d time.Duration) RateLimitOption {
+	return rateLimitOptionFunc(func(l *rateLimiter) {
+		l.markerInterval = d
+	})
+}
+
+// NewRateLimitedCore creates a Core that caps the rate of entries with token
+// buckets, so that a runaway loop can't flood the output. Each level has its
+// own bucket, holding up to burst tokens and refilled at rate tokens per
+// second. Every entry that the wrapped Core would log takes a token, and
+// entries are dropped while their bucket is empty. Entries at DPanicLevel
+// and above are never dropped and take no token. For example, to log at
+// most 50 entries per second, with bursts of up to 500, at each level:
+//
+//	core = zapcore.NewRateLimitedCore(core, 50, 500)
+//
+// Unlike a sampler, which counts entries with the same message and starts
+// over each tick, the limit applies to all entries in a bucket and is
+// enforced continuously.
+//
+// When entries are dropped, the Core writes a marker at WarnLevel, like
+//
+//	{"level":"warn","msg":"rate limit exceeded","limitedLevel":"info","dropped":1200}
+//
+// with the number of entries dropped since the bucket's previous marker.
+// Markers are themselves limited to one per bucket per second, so the
+// count of later drops is reported with the next marker after that, or by
+// Sync. They're written to the Core given to NewRateLimitedCore, without the
+// context added with With.
+//
+// Cores derived from the returned Core with With share its buckets.
+func NewRateLimitedCore(core Core, rate float64, burst int, opts ...RateLimitOption) Core {
+	l := &rateLimiter{
+		root:           core,
+		clock:          DefaultClock,
+		limit:          rateLimit{rate: rate, burst: float64(burst)},
+		levels:         make(map[Level]rateLimit),
+		markerInterval: _defaultRateLimitMarkerInterval,
+		buckets:        make(map[bucketKey]*tokenBucket),
+	}
+	for _, opt := range opts {
+		opt.apply(l)
+	}
+	return &rateLimitedCore{Core: core, l: l}
+}
+
+type rateLimitedCore struct {
+	Core
+
+	l *rateLimiter
+}
+
+var (
+	_ Core           = (*rateLimitedCore)(nil)
+	_ leveledEnabler = (*rateLimitedCore)(nil)
+)
+
+// rateLimiter holds the buckets shared by a rate-limited Core and the Cores
+// derived from it.
+type rateLimiter struct {
+	root           Core // the Core markers are written to
+	clock          Clock
+	limit          rateLimit
+	levels         map[Level]rateLimit
+	byName         bool
+	markerInterval time.Duration
+
+	mu      sync.Mutex
+	buckets map[bucketKey]*tokenBucket
+}
+
+type rateLimit struct {
+	rate  float64 // tokens per second
+	burst float64
+}
+
+// bucketKey identifies a bucket: a level, or a logger name.
+type bucketKey struct {
+	lvl  Level
+	name string
+}
+
+type tokenBucket struct {
+	rateLimit
+
+	tokens     float64
+	last       time.Time // when tokens was last updated
+	dropped    int       // since the last marker
+	nextMarker time.Time
+}
+
+func (c *rateLimitedCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *rateLimitedCore) With(fields []Field) Core {
+	return &rateLimitedCore{Core: c.Core.With(fields), l: c.l}
+}
+
+func (c *rateLimitedCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+	if ent.Level >= DPanicLevel {
+		// Panics and exits must not go unexplained.
+		return c.Core.Check(ent, ce)
+	}
+
+	// Only entries that the wrapped Core adds itself for take a token, so
+	// remember what ce held to undo the wrapped Core's changes if the
+	// bucket is empty.
+	var (
+		cores, before int
+		after         CheckWriteHook
+	)
+	if ce != nil {
+		cores, before, after = len(ce.cores), len(ce.before), ce.after
+	}
+	checked := c.Core.Check(ent, ce)
+	if checked == nil || len(checked.cores) == cores || c.l.allow(ent) {
+		return checked
+	}
+	if ce == nil {
+		putCheckedEntry(checked)
+		return nil
+	}
+	for i := cores; i < len(ce.cores); i++ {
+		ce.cores[i] = nil
+	}
+	ce.cores = ce.cores[:cores]
+	for i := before; i < len(ce.before); i++ {
+		ce.before[i] = nil
+	}
+	ce.before = ce.before[:before]
+	ce.after = after
+	return ce
+}
+
+// Sync writes markers for all buckets with unreported drops, then syncs the
+// wrapped Core.
+func (c *rateLimitedCore) Sync() error {
+	now := c.l.clock.Now()
+
+	c.l.mu.Lock()
+	var markers []rateLimitMarker
+	for key, b := range c.l.buckets {
+		if b.dropped > 0 {
+			markers = append(markers, rateLimitMarker{key: key, dropped: b.dropped})
+			b.dropped = 0
+			b.nextMarker = now.Add(c.l.markerInterval)
+		}
+	}
+	c.l.mu.Unlock()
+
+	var err error
+	for _, m := range markers {
+		if ent, fields, ok := c.l.marker(m, now); ok {
+			err = multierr.Append(err, c.l.root.Write(ent, fields))
+		}
+	}
+	return multierr.Append(err, c.Core.Sync())
+}
+
+// allow takes a token from the entry's bucket, and reports whether there
+// was one. It writes a marker if the entry is dropped and one is due.
+func (l *rateLimiter) allow(ent Entry) bool {
+	now := l.clock.Now()
+	key := bucketKey{lvl: ent.Level}
+	limit, ok := l.levels[ent.Level]
+	if l.byName {
+		key = bucketKey{name: ent.LoggerName}
+	}
+	if !ok || l.byName {
+		limit = l.limit
+	}
+
+	l.mu.Lock()
+	b := l.buckets[key]
+	if b == nil {
+		b = &tokenBucket{rateLimit: limit, tokens: limit.burst, last: now}
+		l.buckets[key] = b
+	}
+	if b.take(now) {
+		l.mu.Unlock()
+		return true
+	}
+	b.dropped++
+	if now.Before(b.nextMarker) {
+		l.mu.Unlock()
+		return false
+	}
+	m := rateLimitMarker{key: key, dropped: b.dropped}
+	b.dropped = 0
+	b.nextMarker = now.Add(l.markerInterval)
+	l.mu.Unlock()
+
+	if ent, fields, ok := l.marker(m, now); ok {
+		writeFromCheck(l.root, ent, fields)
+	}
+	return false
+}
+
+// take refills the bucket and takes a token if there is one.
+func (b *tokenBucket) take(now time.Time) bool {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// rateLimitMarker reports entries dropped from a bucket.
+type rateLimitMarker struct {
+	key     bucketKey
+	dropped int
+}
+
+// marker builds the entry that reports a marker. It reports false if the
+// Core given to NewRateLimitedCore doesn't log at WarnLevel.
+func (l *rateLimiter) marker(m rateLimitMarker, now time.Time) (Entry, []Field, bool) {
+	if !l.root.Enabled(WarnLevel) {
+		return Entry{}, nil, false
+	}
+
+	ent := Entry{Level: WarnLevel, Time: now, Message: "rate limit exceeded"}
+	limited := Field{Key: "limitedLevel", Type: StringType, String: m.key.lvl.String()}
+	if l.byName {
+		limited = Field{Key: "limitedLogger", Type: StringType, String: m.key.name}
+	}
+	return ent, []Field{
+		limited,
+		{Key: "dropped", Type: Int64Type, Integer: int64(m.dropped)},
+	}, true
+}
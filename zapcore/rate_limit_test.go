@@ -0,0 +1,198 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap/internal/ztest"
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newRateLimitedCore(t *testing.T, rate float64, burst int, opts ...RateLimitOption) (Core, *ztest.MockClock, *observer.ObservedLogs) {
+	t.Helper()
+	clock := ztest.NewMockClock()
+	core, logs := observer.New(DebugLevel)
+	opts = append([]RateLimitOption{RateLimitClock(clock)}, opts...)
+	return NewRateLimitedCore(core, rate, burst, opts...), clock, logs
+}
+
+func countMessages(logs *observer.ObservedLogs, msg string) int {
+	return logs.FilterMessage(msg).Len()
+}
+
+func TestRateLimitedCore(t *testing.T) {
+	core, clock, logs := newRateLimitedCore(t, 10, 5)
+	assert.Equal(t, DebugLevel, LevelOf(core), "Unexpected level.")
+
+	for i := 0; i < 20; i++ {
+		writeEntry(core, InfoLevel, "info")
+	}
+	writeEntry(core.With([]Field{makeInt64Field("k", 1)}), WarnLevel, "warn")
+	assert.Equal(t, 5, countMessages(logs, "info"), "Expected a burst of entries.")
+	assert.Equal(t, 1, countMessages(logs, "warn"), "Expected levels to have their own buckets.")
+
+	markers := logs.FilterMessage("rate limit exceeded").AllUntimed()
+	require.Len(t, markers, 1, "Expected a single marker for the first drop.")
+	assert.Equal(t, WarnLevel, markers[0].Level, "Unexpected marker level.")
+	assert.Equal(t, map[string]interface{}{"limitedLevel": "info", "dropped": int64(1)}, markers[0].ContextMap(),
+		"Unexpected marker fields.")
+	logs.TakeAll()
+
+	// Half a second refills five tokens, but markers are limited to one per
+	// second.
+	clock.Add(500 * time.Millisecond)
+	for i := 0; i < 7; i++ {
+		writeEntry(core, InfoLevel, "info")
+	}
+	assert.Equal(t, 5, countMessages(logs, "info"), "Expected the bucket to be refilled at the rate.")
+	assert.Zero(t, countMessages(logs, "rate limit exceeded"), "Expected markers to be rate limited.")
+
+	clock.Add(500 * time.Millisecond)
+	for i := 0; i < 6; i++ {
+		writeEntry(core, InfoLevel, "info")
+	}
+	markers = logs.FilterMessage("rate limit exceeded").AllUntimed()
+	require.Len(t, markers, 1, "Expected a marker once a second has passed.")
+	assert.Equal(t, int64(14+2+1), markers[0].ContextMap()["dropped"], "Expected the marker to count drops since the last one.")
+}
+
+func TestRateLimitedCoreBurstCap(t *testing.T) {
+	core, clock, logs := newRateLimitedCore(t, 100, 3)
+
+	clock.Add(time.Hour)
+	for i := 0; i < 10; i++ {
+		writeEntry(core, InfoLevel, "info")
+	}
+	assert.Equal(t, 3, countMessages(logs, "info"), "Expected the bucket to hold at most burst tokens.")
+}
+
+func TestRateLimitedCoreLevelOverrides(t *testing.T) {
+	core, _, logs := newRateLimitedCore(t, 1, 1, RateLimitLevel(ErrorLevel, 100, 100))
+
+	for i := 0; i < 10; i++ {
+		writeEntry(core, DebugLevel, "debug")
+		writeEntry(core, ErrorLevel, "error")
+	}
+	assert.Equal(t, 1, countMessages(logs, "debug"), "Unexpected number of debug entries.")
+	assert.Equal(t, 10, countMessages(logs, "error"), "Expected the level's own limit.")
+}
+
+func TestRateLimitedCoreByLoggerName(t *testing.T) {
+	core, _, logs := newRateLimitedCore(t, 1, 2, RateLimitByLoggerName())
+
+	for i := 0; i < 3; i++ {
+		for _, name := range []string{"a", "b"} {
+			if ce := core.Check(Entry{Level: InfoLevel, LoggerName: name, Message: name}, nil); ce != nil {
+				ce.Write()
+			}
+		}
+		writeEntry(core, ErrorLevel, "unnamed")
+	}
+	assert.Equal(t, 2, countMessages(logs, "a"), "Unexpected number of entries for logger a.")
+	assert.Equal(t, 2, countMessages(logs, "b"), "Unexpected number of entries for logger b.")
+	assert.Equal(t, 2, countMessages(logs, "unnamed"), "Expected levels to share a logger's bucket.")
+
+	var limited []interface{}
+	for _, m := range logs.FilterMessage("rate limit exceeded").AllUntimed() {
+		limited = append(limited, m.ContextMap()["limitedLogger"])
+	}
+	assert.ElementsMatch(t, []interface{}{"a", "b", ""}, limited, "Expected a marker per logger.")
+}
+
+func TestRateLimitedCoreSync(t *testing.T) {
+	core, _, logs := newRateLimitedCore(t, 1, 1, RateLimitMarkerInterval(time.Hour))
+
+	for i := 0; i < 5; i++ {
+		writeEntry(core, InfoLevel, "info")
+	}
+	require.Equal(t, 1, countMessages(logs, "rate limit exceeded"), "Expected a marker for the first drop.")
+	logs.TakeAll()
+
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	markers := logs.TakeAll()
+	require.Len(t, markers, 1, "Expected Sync to report remaining drops.")
+	assert.Equal(t, int64(3), markers[0].ContextMap()["dropped"], "Unexpected dropped count.")
+
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	assert.Zero(t, logs.Len(), "Expected no marker without drops.")
+}
+
+func TestRateLimitedCoreDisabledMarkers(t *testing.T) {
+	inner, logs := observer.New(ErrorLevel)
+	core := NewRateLimitedCore(inner, 0, 1)
+
+	writeEntry(core, InfoLevel, "disabled")
+	writeEntry(core, ErrorLevel, "error")
+	writeEntry(core, ErrorLevel, "error")
+	assert.NoError(t, core.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, 1, logs.Len(), "Expected no markers if WarnLevel is disabled.")
+}
+
+func TestRateLimitedCoreOnlyCountsLoggedEntries(t *testing.T) {
+	debugCore, debugLogs := observer.New(DebugLevel)
+	rejecting := NewSamplerWithOptions(debugCore, time.Hour, 1, 0)
+	core := NewRateLimitedCore(rejecting, 0, 2, RateLimitClock(ztest.NewMockClock()))
+
+	for i := 0; i < 5; i++ {
+		writeEntry(core, InfoLevel, "sampled")
+	}
+	writeEntry(core, InfoLevel, "other")
+	assert.Equal(t, []string{"sampled", "other"}, messages(debugLogs),
+		"Expected entries filtered by the wrapped Core not to take tokens.")
+
+	ce := core.Check(Entry{Level: InfoLevel, Message: "limited"}, nil)
+	assert.Nil(t, ce, "Expected entries to be dropped once the bucket is empty.")
+}
+
+func TestRateLimitedCoreKeepsPanicsAndFatals(t *testing.T) {
+	core, _, logs := newRateLimitedCore(t, 0, 1, RateLimitMarkerInterval(time.Hour))
+
+	for _, lvl := range []Level{ErrorLevel, ErrorLevel, DPanicLevel, PanicLevel, FatalLevel} {
+		writeEntry(core, lvl, lvl.String())
+	}
+	assert.Equal(t, 1, countMessages(logs, "error"), "Expected errors to be rate limited.")
+	for _, lvl := range []Level{DPanicLevel, PanicLevel, FatalLevel} {
+		assert.Equal(t, 1, countMessages(logs, lvl.String()), "Expected %v entries not to be rate limited.", lvl)
+	}
+}
+
+func TestRateLimitedCoreRestoresCheckedEntry(t *testing.T) {
+	inner, logs := observer.New(DebugLevel)
+	other, otherLogs := observer.New(DebugLevel)
+	core := NewRateLimitedCore(inner, 0, 1, RateLimitClock(ztest.NewMockClock()))
+
+	for i := 0; i < 2; i++ {
+		ent := Entry{Level: InfoLevel, Message: "msg"}
+		ce := other.Check(ent, nil)
+		ce = core.Check(ent, ce)
+		require.NotNil(t, ce, "Expected the other Core to keep the entry.")
+		ce.Write()
+	}
+	assert.Equal(t, 1, logs.FilterMessage("msg").Len(), "Expected the second entry to be rate limited.")
+	assert.Equal(t, 2, otherLogs.Len(), "Expected Cores added before the rate-limited Core to keep the entry.")
+}
@@ -0,0 +1,554 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"bytes"
+	"encoding/json"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultRedactionMask replaces redacted values unless RedactionMask says
+// otherwise.
+const DefaultRedactionMask = "[REDACTED]"
+
+// A RedactionRule tells a Core created with NewRedactingCore what to redact.
+type RedactionRule interface {
+	apply(*redactor)
+}
+
+type redactionRuleFunc func(*redactor)
+
+func (f redactionRuleFunc) apply(r *redactor) {
+	f(r)
+}
+
+// RedactKeys masks the values of fields whose keys match any of the given
+// glob patterns, as understood by path.Match. Matching is case-insensitive,
+// so "*password*" matches "password", "dbPassword", and "PASSWORD_HASH".
+// Patterns that aren't valid globs only match keys equal to them.
+//
+// Keys are matched at every level of nesting: the pattern "token" matches
+// the token key of an object logged with zap.Object, as well as a top-level
+// field named token.
+func RedactKeys(patterns ...string) RedactionRule {
+	return redactionRuleFunc(func(r *redactor) {
+		for _, p := range patterns {
+			p := strings.ToLower(p)
+			if _, err := path.Match(p, ""); err != nil {
+				r.keys = append(r.keys, func(key string) bool {
+					return strings.ToLower(key) == p
+				})
+				continue
+			}
+			r.keys = append(r.keys, func(key string) bool {
+				ok, _ := path.Match(p, strings.ToLower(key))
+				return ok
+			})
+		}
+	})
+}
+
+// RedactKeysMatching masks the values of fields whose keys match the
+// regular expression.
+func RedactKeysMatching(re *regexp.Regexp) RedactionRule {
+	return redactionRuleFunc(func(r *redactor) {
+		r.keys = append(r.keys, re.MatchString)
+	})
+}
+
+// RedactValuesMatching replaces the parts of string values that match the
+// regular expression with the mask, regardless of their keys.
+func RedactValuesMatching(re *regexp.Regexp) RedactionRule {
+	return redactionRuleFunc(func(r *redactor) {
+		r.values = append(r.values, func(s, mask string) string {
+			return re.ReplaceAllLiteralString(s, mask)
+		})
+	})
+}
+
+var (
+	_emailRegexp       = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	_creditCardRegexp  = regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`)
+	_bearerTokenRegexp = regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*`)
+)
+
+// RedactEmails replaces email addresses in string values with the mask.
+func RedactEmails() RedactionRule {
+	return RedactValuesMatching(_emailRegexp)
+}
+
+// RedactCreditCards replaces credit card numbers in string values with the
+// mask. Numbers may contain spaces or dashes between digits, and must pass
+// the Luhn check to be redacted.
+func RedactCreditCards() RedactionRule {
+	return redactionRuleFunc(func(r *redactor) {
+		r.values = append(r.values, func(s, mask string) string {
+			return _creditCardRegexp.ReplaceAllStringFunc(s, func(match string) string {
+				if !luhnValid(match) {
+					return match
+				}
+				return mask
+			})
+		})
+	})
+}
+
+// RedactBearerTokens replaces bearer tokens in string values, such as the
+// value of an Authorization header, with the mask.
+func RedactBearerTokens() RedactionRule {
+	return RedactValuesMatching(_bearerTokenRegexp)
+}
+
+// RedactionMask sets the string that replaces redacted values. It defaults
+// to DefaultRedactionMask.
+func RedactionMask(mask string) RedactionRule {
+	return redactionRuleFunc(func(r *redactor) {
+		r.mask = mask
+	})
+}
+
+// luhnValid reports whether the digits in s pass the Luhn checksum.
+func luhnValid(s string) bool {
+	var sum, n int
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return sum%10 == 0
+}
+
+// NewRedactingCore creates a Core that redacts Fields before passing them
+// to the wrapped Core, so that secrets don't reach the encoder. It applies
+// to Fields added with With as well as those passed at the log site, and
+// looks inside objects, arrays, errors, Stringers, and values logged by
+// reflection, such as a struct logged with zap.Any.
+//
+// For example, the following masks passwords and authorization headers,
+// and scrubs email addresses from all values:
+//
+//	core = zapcore.NewRedactingCore(core,
+//		zapcore.RedactKeys("*password*", "authorization"),
+//		zapcore.RedactEmails(),
+//	)
+//
+// Values of matching keys are replaced with the mask, whatever their type.
+// Fields whose values need to be inspected during encoding, such as
+// objects, are replaced with inline Fields that redact while encoding.
+// Values logged by reflection are converted to JSON-compatible maps and
+// slices to be inspected, which is expensive.
+//
+// The entry's message isn't redacted.
+func NewRedactingCore(core Core, rules ...RedactionRule) Core {
+	r := &redactor{mask: DefaultRedactionMask}
+	for _, rule := range rules {
+		rule.apply(r)
+	}
+	return &redactingCore{Core: core, r: r}
+}
+
+type redactingCore struct {
+	Core
+
+	r *redactor
+}
+
+var (
+	_ Core           = (*redactingCore)(nil)
+	_ leveledEnabler = (*redactingCore)(nil)
+)
+
+func (c *redactingCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *redactingCore) With(fields []Field) Core {
+	return &redactingCore{Core: c.Core.With(c.r.redactFields(fields)), r: c.r}
+}
+
+func (c *redactingCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *redactingCore) Write(ent Entry, fields []Field) error {
+	return checkAndWrite(c.Core, ent, c.r.redactFields(fields))
+}
+
+// redactor holds the rules of a redacting Core.
+type redactor struct {
+	keys   []func(key string) bool
+	values []func(s, mask string) string
+	mask   string
+}
+
+func (r *redactor) redactKey(key string) bool {
+	for _, match := range r.keys {
+		if match(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *redactor) redactString(s string) string {
+	for _, replace := range r.values {
+		s = replace(s, r.mask)
+	}
+	return s
+}
+
+// redactFields returns the fields with redacted values. It copies fields
+// before making any changes, since the caller may reuse them.
+func (r *redactor) redactFields(fields []Field) []Field {
+	var out []Field
+	for i, f := range fields {
+		rf, changed := r.redactField(f)
+		if !changed {
+			if out != nil {
+				out = append(out, f)
+			}
+			continue
+		}
+		if out == nil {
+			out = make([]Field, i, len(fields))
+			copy(out, fields[:i])
+		}
+		out = append(out, rf)
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+func (r *redactor) redactField(f Field) (Field, bool) {
+	switch f.Type {
+	case SkipType, NamespaceType:
+		return f, false
+	}
+	if r.redactKey(f.Key) {
+		return Field{Key: f.Key, Type: StringType, String: r.mask}, true
+	}
+
+	switch f.Type {
+	case StringType:
+		s := r.redactString(f.String)
+		if s == f.String {
+			return f, false
+		}
+		return Field{Key: f.Key, Type: StringType, String: s}, true
+	case ByteStringType:
+		b := f.Interface.([]byte)
+		s := r.redactString(string(b))
+		if s == string(b) {
+			return f, false
+		}
+		return Field{Key: f.Key, Type: StringType, String: s}, true
+	case ArrayMarshalerType, ObjectMarshalerType, InlineMarshalerType,
+		ReflectType, StringerType, ErrorType:
+		// These are only known once encoded, so redact while encoding.
+		return Field{Type: InlineMarshalerType, Interface: redactedField{f, r}}, true
+	default:
+		return f, false
+	}
+}
+
+// redactedField is an ObjectMarshaler that adds a Field to the encoder
+// through a redactingObjectEncoder.
+type redactedField struct {
+	f Field
+	r *redactor
+}
+
+func (rf redactedField) MarshalLogObject(enc ObjectEncoder) error {
+	rf.f.AddTo(&redactingObjectEncoder{enc, rf.r})
+	return nil
+}
+
+// redactedObject and redactedArray redact the contents of nested marshalers.
+type redactedObject struct {
+	obj ObjectMarshaler
+	r   *redactor
+}
+
+func (ro redactedObject) MarshalLogObject(enc ObjectEncoder) error {
+	return ro.obj.MarshalLogObject(&redactingObjectEncoder{enc, ro.r})
+}
+
+type redactedArray struct {
+	arr ArrayMarshaler
+	r   *redactor
+}
+
+func (ra redactedArray) MarshalLogArray(enc ArrayEncoder) error {
+	return ra.arr.MarshalLogArray(&redactingArrayEncoder{enc, ra.r})
+}
+
+// redactReflected converts a value logged by reflection to maps, slices, and
+// primitives, and redacts them. If the value can't be converted, it's
+// returned as is so that the encoder reports the error.
+func (r *redactor) redactReflected(obj interface{}) interface{} {
+	if obj == nil {
+		return nil
+	}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return obj
+	}
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return obj
+	}
+	return r.redactJSON(v)
+}
+
+func (r *redactor) redactJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if r.redactKey(k) {
+				v[k] = r.mask
+			} else {
+				v[k] = r.redactJSON(val)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, val := range v {
+			v[i] = r.redactJSON(val)
+		}
+		return v
+	case string:
+		return r.redactString(v)
+	default:
+		return v
+	}
+}
+
+// redactingObjectEncoder redacts values before adding them to the wrapped
+// ObjectEncoder.
+type redactingObjectEncoder struct {
+	ObjectEncoder
+
+	r *redactor
+}
+
+func (e *redactingObjectEncoder) masked(key string) bool {
+	if e.r.redactKey(key) {
+		e.ObjectEncoder.AddString(key, e.r.mask)
+		return true
+	}
+	return false
+}
+
+func (e *redactingObjectEncoder) AddArray(key string, arr ArrayMarshaler) error {
+	if e.masked(key) {
+		return nil
+	}
+	return e.ObjectEncoder.AddArray(key, redactedArray{arr, e.r})
+}
+
+func (e *redactingObjectEncoder) AddObject(key string, obj ObjectMarshaler) error {
+	if e.masked(key) {
+		return nil
+	}
+	return e.ObjectEncoder.AddObject(key, redactedObject{obj, e.r})
+}
+
+func (e *redactingObjectEncoder) AddReflected(key string, obj interface{}) error {
+	if e.masked(key) {
+		return nil
+	}
+	return e.ObjectEncoder.AddReflected(key, e.r.redactReflected(obj))
+}
+
+func (e *redactingObjectEncoder) AddString(key, val string) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddString(key, e.r.redactString(val))
+	}
+}
+
+func (e *redactingObjectEncoder) AddByteString(key string, val []byte) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddString(key, e.r.redactString(string(val)))
+	}
+}
+
+func (e *redactingObjectEncoder) AddBinary(key string, val []byte) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddBinary(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddBool(key string, val bool) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddBool(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddComplex128(key string, val complex128) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddComplex128(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddComplex64(key string, val complex64) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddComplex64(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddDuration(key string, val time.Duration) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddDuration(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddFloat64(key string, val float64) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddFloat64(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddFloat32(key string, val float32) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddFloat32(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddInt(key string, val int) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddInt(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddInt64(key string, val int64) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddInt64(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddInt32(key string, val int32) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddInt32(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddInt16(key string, val int16) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddInt16(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddInt8(key string, val int8) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddInt8(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddTime(key string, val time.Time) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddTime(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddUint(key string, val uint) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddUint(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddUint64(key string, val uint64) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddUint64(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddUint32(key string, val uint32) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddUint32(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddUint16(key string, val uint16) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddUint16(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddUint8(key string, val uint8) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddUint8(key, val)
+	}
+}
+
+func (e *redactingObjectEncoder) AddUintptr(key string, val uintptr) {
+	if !e.masked(key) {
+		e.ObjectEncoder.AddUintptr(key, val)
+	}
+}
+
+// redactingArrayEncoder redacts values before appending them to the wrapped
+// ArrayEncoder. Array elements have no keys, so only values are redacted.
+type redactingArrayEncoder struct {
+	ArrayEncoder
+
+	r *redactor
+}
+
+func (e *redactingArrayEncoder) AppendArray(arr ArrayMarshaler) error {
+	return e.ArrayEncoder.AppendArray(redactedArray{arr, e.r})
+}
+
+func (e *redactingArrayEncoder) AppendObject(obj ObjectMarshaler) error {
+	return e.ArrayEncoder.AppendObject(redactedObject{obj, e.r})
+}
+
+func (e *redactingArrayEncoder) AppendReflected(val interface{}) error {
+	return e.ArrayEncoder.AppendReflected(e.r.redactReflected(val))
+}
+
+func (e *redactingArrayEncoder) AppendString(val string) {
+	e.ArrayEncoder.AppendString(e.r.redactString(val))
+}
+
+func (e *redactingArrayEncoder) AppendByteString(val []byte) {
+	e.ArrayEncoder.AppendString(e.r.redactString(string(val)))
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/internal/ztest"
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+)
+
+type redactUser struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+	Email    string `json:"email"`
+}
+
+func (u redactUser) MarshalLogObject(enc ObjectEncoder) error {
+	enc.AddString("name", u.Name)
+	enc.AddString("password", u.Password)
+	enc.AddString("email", u.Email)
+	return nil
+}
+
+func TestRedactingCore(t *testing.T) {
+	user := redactUser{Name: "alice", Password: "hunter2", Email: "alice@example.com"}
+
+	tests := []struct {
+		desc   string
+		fields []Field
+		want   string
+	}{
+		{
+			desc:   "matching keys",
+			fields: []Field{zap.String("password", "hunter2"), zap.Int("dbPassword", 1234), zap.String("Authorization", "Basic abc")},
+			want:   `{"password":"[REDACTED]","dbPassword":"[REDACTED]","Authorization":"[REDACTED]"}`,
+		},
+		{
+			desc:   "unmatched fields",
+			fields: []Field{zap.String("user", "alice"), zap.Int("count", 3), zap.Namespace("ns"), zap.Bool("ok", true)},
+			want:   `{"user":"alice","count":3,"ns":{"ok":true}}`,
+		},
+		{
+			desc:   "value detectors",
+			fields: []Field{zap.String("to", "mail alice@example.com now"), zap.ByteString("card", []byte("4111 1111 1111 1111")), zap.String("header", "Bearer abc.def-ghi")},
+			want:   `{"to":"mail [REDACTED] now","card":"[REDACTED]","header":"[REDACTED]"}`,
+		},
+		{
+			desc:   "invalid card numbers",
+			fields: []Field{zap.String("order", "1234 5678 9012 3456")},
+			want:   `{"order":"1234 5678 9012 3456"}`,
+		},
+		{
+			desc:   "objects",
+			fields: []Field{zap.Object("user", user)},
+			want:   `{"user":{"name":"alice","password":"[REDACTED]","email":"[REDACTED]"}}`,
+		},
+		{
+			desc:   "arrays",
+			fields: []Field{zap.Objects("users", []redactUser{user}), zap.Strings("emails", []string{"bob@example.com", "none"})},
+			want:   `{"users":[{"name":"alice","password":"[REDACTED]","email":"[REDACTED]"}],"emails":["[REDACTED]","none"]}`,
+		},
+		{
+			desc:   "reflection",
+			fields: []Field{zap.Reflect("user", user), zap.Any("users", []interface{}{map[string]string{"secretToken": "x"}, 42})},
+			want:   `{"user":{"name":"alice","password":"[REDACTED]","email":"[REDACTED]"},"users":[{"secretToken":"[REDACTED]"},42]}`,
+		},
+		{
+			desc:   "errors and stringers",
+			fields: []Field{zap.Error(errors.New("no user bob@example.com")), zap.Stringer("addr", redactStringer("carol@example.com"))},
+			want:   `{"error":"no user [REDACTED]","addr":"[REDACTED]"}`,
+		},
+		{
+			desc:   "inline",
+			fields: []Field{zap.Inline(user)},
+			want:   `{"name":"alice","password":"[REDACTED]","email":"[REDACTED]"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf := &ztest.Buffer{}
+			core := NewRedactingCore(
+				NewCore(NewJSONEncoder(EncoderConfig{SkipLineEnding: true}), buf, DebugLevel),
+				RedactKeys("*password*", "authorization", "*token*", "[bad"),
+				RedactEmails(),
+				RedactCreditCards(),
+				RedactBearerTokens(),
+			)
+			fields := append([]Field(nil), tt.fields...)
+			require.NoError(t, core.Write(Entry{}, fields), "Unexpected write error.")
+			assert.JSONEq(t, tt.want, buf.String(), "Unexpected output.")
+			assert.Equal(t, tt.fields, fields, "Expected the caller's fields to be left alone.")
+		})
+	}
+}
+
+type redactStringer string
+
+func (s redactStringer) String() string { return string(s) }
+
+func TestRedactingCoreWith(t *testing.T) {
+	buf := &ztest.Buffer{}
+	core := NewRedactingCore(
+		NewCore(NewJSONEncoder(EncoderConfig{MessageKey: "msg"}), buf, InfoLevel),
+		RedactKeysMatching(regexp.MustCompile(`^api_?key$`)),
+		RedactValuesMatching(regexp.MustCompile(`\d{3}-\d{2}-\d{4}`)),
+		RedactionMask("***"),
+	)
+	core = core.With([]Field{zap.String("apikey", "abc"), zap.String("ssn", "id 123-45-6789")})
+
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+	assert.Nil(t, core.Check(Entry{Level: DebugLevel}, nil), "Expected disabled levels to be dropped.")
+	ce := core.Check(Entry{Level: InfoLevel, Message: "hello"}, nil)
+	require.NotNil(t, ce, "Expected enabled levels to be logged.")
+	ce.Write(zap.String("api_key", "def"))
+
+	assert.Equal(t, []string{`{"msg":"hello","apikey":"***","ssn":"id ***","api_key":"***"}`}, buf.Lines(), "Unexpected output.")
+}
+
+func TestRedactingCoreChecksWrappedCore(t *testing.T) {
+	buf := &ztest.Buffer{}
+	sampler := NewSamplerWithOptions(
+		NewCore(NewJSONEncoder(EncoderConfig{MessageKey: "msg"}), buf, InfoLevel),
+		time.Minute, 1, 0,
+	)
+	core := NewRedactingCore(sampler, RedactKeys("password"))
+
+	for i := 0; i < 5; i++ {
+		if ce := core.Check(Entry{Level: InfoLevel, Message: "login", Time: time.Now()}, nil); ce != nil {
+			ce.Write(zap.String("password", "hunter2"))
+		}
+	}
+	assert.Equal(t, []string{`{"msg":"login","password":"[REDACTED]"}`}, buf.Lines(), "Expected the wrapped sampler to drop repeats.")
+}
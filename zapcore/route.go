@@ -0,0 +1,255 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"path"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+// RoutingMode determines which routes a routing Core sends an entry to.
+type RoutingMode int8
+
+const (
+	// RouteFirstMatch sends each entry to the first route whose rule matches
+	// it.
+	RouteFirstMatch RoutingMode = iota
+	// RouteAllMatches sends each entry to every route whose rule matches it.
+	RouteAllMatches
+)
+
+// RouteRule matches entries by level, logger name, message, and fields. An
+// entry matches the rule if it matches every criterion that's set, so the
+// zero RouteRule matches all entries.
+type RouteRule struct {
+	// Level, if set, matches entries at the levels it enables.
+	Level LevelEnabler
+	// LoggerName, if set, is a glob pattern, as understood by path.Match,
+	// matching the logger name. For example, "http.*" matches the loggers
+	// named "http.client" and "http.server".
+	LoggerName string
+	// MessagePrefix, if set, matches messages that start with it.
+	MessagePrefix string
+	// Fields, if set, maps field keys to glob patterns that the fields'
+	// values must match, such as {"audit": "*"} to match entries with an
+	// audit field, or {"tenant": "acme"}. Fields added with With and those
+	// passed at the log site are both considered. Values other than strings,
+	// integers, booleans, and durations only match "*".
+	Fields map[string]string
+}
+
+// A Route sends the entries matching its Rule to a Core.
+type Route struct {
+	Rule RouteRule
+	Core Core
+}
+
+// NewRoutingCore creates a Core that sends each entry to the routes whose
+// rules match it, following mode, or to fallback if none match. Fallback
+// may be nil to drop entries that match no route. For example, to write
+// audit entries to one Core and everything else to another:
+//
+//	core := zapcore.NewRoutingCore(zapcore.RouteFirstMatch, []zapcore.Route{
+//	  {Rule: zapcore.RouteRule{Fields: map[string]string{"audit": "*"}}, Core: auditCore},
+//	}, appCore)
+//
+// Rules on fields need the fields passed at the log site, so if any route
+// has one, the routes are chosen in Write instead of Check.
+func NewRoutingCore(mode RoutingMode, routes []Route, fallback Core) Core {
+	c := &routingCore{
+		mode:     mode,
+		routes:   make([]Route, len(routes)),
+		fallback: fallback,
+	}
+	copy(c.routes, routes)
+	for _, r := range routes {
+		for key := range r.Rule.Fields {
+			if c.fieldKeys == nil {
+				c.fieldKeys = make(map[string]struct{})
+			}
+			c.fieldKeys[key] = struct{}{}
+		}
+	}
+	return c
+}
+
+type routingCore struct {
+	mode      RoutingMode
+	routes    []Route
+	fallback  Core                // may be nil
+	fieldKeys map[string]struct{} // keys used by rules on fields
+	ctx       []Field             // context fields with keys in fieldKeys
+}
+
+var (
+	_ Core           = (*routingCore)(nil)
+	_ leveledEnabler = (*routingCore)(nil)
+)
+
+func (c *routingCore) cores() []Core {
+	cores := make([]Core, 0, len(c.routes)+1)
+	for _, r := range c.routes {
+		cores = append(cores, r.Core)
+	}
+	if c.fallback != nil {
+		cores = append(cores, c.fallback)
+	}
+	return cores
+}
+
+func (c *routingCore) Level() Level {
+	return LevelOf(NewTee(c.cores()...))
+}
+
+func (c *routingCore) Enabled(lvl Level) bool {
+	for _, r := range c.routes {
+		if (r.Rule.Level == nil || r.Rule.Level.Enabled(lvl)) && r.Core.Enabled(lvl) {
+			return true
+		}
+	}
+	return c.fallback != nil && c.fallback.Enabled(lvl)
+}
+
+func (c *routingCore) With(fields []Field) Core {
+	clone := &routingCore{
+		mode:      c.mode,
+		routes:    make([]Route, len(c.routes)),
+		fieldKeys: c.fieldKeys,
+		ctx:       c.ctx[:len(c.ctx):len(c.ctx)],
+	}
+	for i, r := range c.routes {
+		clone.routes[i] = Route{Rule: r.Rule, Core: r.Core.With(fields)}
+	}
+	if c.fallback != nil {
+		clone.fallback = c.fallback.With(fields)
+	}
+	for _, f := range fields {
+		if _, ok := c.fieldKeys[f.Key]; ok {
+			clone.ctx = append(clone.ctx, f)
+		}
+	}
+	return clone
+}
+
+func (c *routingCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.fieldKeys != nil {
+		// Rules on fields need the fields passed to Write.
+		if c.Enabled(ent.Level) {
+			return ce.AddCore(ent, c)
+		}
+		return ce
+	}
+
+	matched := false
+	for _, r := range c.routes {
+		if r.Rule.matchEntry(ent) {
+			ce = r.Core.Check(ent, ce)
+			matched = true
+			if c.mode == RouteFirstMatch {
+				break
+			}
+		}
+	}
+	if !matched && c.fallback != nil {
+		ce = c.fallback.Check(ent, ce)
+	}
+	return ce
+}
+
+func (c *routingCore) Write(ent Entry, fields []Field) error {
+	// Check only added the routing Core, so each route's Core still gets
+	// to filter the entry in its own Check.
+	var err error
+	matched := false
+	for _, r := range c.routes {
+		if r.Rule.matchEntry(ent) && r.Rule.matchFields(c.ctx, fields) {
+			err = multierr.Append(err, checkAndWrite(r.Core, ent, fields))
+			matched = true
+			if c.mode == RouteFirstMatch {
+				break
+			}
+		}
+	}
+	if !matched && c.fallback != nil {
+		err = multierr.Append(err, checkAndWrite(c.fallback, ent, fields))
+	}
+	return err
+}
+
+func (c *routingCore) Sync() error {
+	var err error
+	for _, core := range c.cores() {
+		err = multierr.Append(err, core.Sync())
+	}
+	return err
+}
+
+// matchEntry reports whether the entry matches the rule's criteria, other
+// than those on fields.
+func (r RouteRule) matchEntry(ent Entry) bool {
+	if r.Level != nil && !r.Level.Enabled(ent.Level) {
+		return false
+	}
+	if r.LoggerName != "" {
+		if ok, _ := path.Match(r.LoggerName, ent.LoggerName); !ok {
+			return false
+		}
+	}
+	return strings.HasPrefix(ent.Message, r.MessagePrefix)
+}
+
+// matchFields reports whether the context and log site fields match the
+// rule's criteria on fields. Log site fields take precedence.
+func (r RouteRule) matchFields(ctx, fields []Field) bool {
+	for key, pattern := range r.Fields {
+		f, ok := lastField(key, fields)
+		if !ok {
+			f, ok = lastField(key, ctx)
+		}
+		if !ok || !matchFieldValue(pattern, f) {
+			return false
+		}
+	}
+	return true
+}
+
+func lastField(key string, fields []Field) (Field, bool) {
+	for i := len(fields) - 1; i >= 0; i-- {
+		if fields[i].Key == key {
+			return fields[i], true
+		}
+	}
+	return Field{}, false
+}
+
+func matchFieldValue(pattern string, f Field) bool {
+	if pattern == "*" {
+		return true
+	}
+	val, ok := fieldValueString(f)
+	if !ok {
+		return false
+	}
+	matched, _ := path.Match(pattern, val)
+	return matched
+}
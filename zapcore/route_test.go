@@ -0,0 +1,167 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func routeEntry(core Core, ent Entry, fields ...Field) {
+	if ce := core.Check(ent, nil); ce != nil {
+		ce.Write(fields...)
+	}
+}
+
+func TestRoutingCore(t *testing.T) {
+	errorsCore, errorLogs := observer.New(DebugLevel)
+	httpCore, httpLogs := observer.New(InfoLevel)
+	fallback, fallbackLogs := observer.New(DebugLevel)
+	routes := []Route{
+		{Rule: RouteRule{Level: ErrorLevel}, Core: errorsCore},
+		{Rule: RouteRule{LoggerName: "http.*", MessagePrefix: "request"}, Core: httpCore},
+	}
+
+	tests := []struct {
+		mode         RoutingMode
+		wantErrors   []string
+		wantHTTP     []string
+		wantFallback []string
+	}{
+		{
+			mode:         RouteFirstMatch,
+			wantErrors:   []string{"request failed"},
+			wantHTTP:     []string{"request handled"},
+			wantFallback: []string{"starting", "response sent"},
+		},
+		{
+			mode:         RouteAllMatches,
+			wantErrors:   []string{"request failed"},
+			wantHTTP:     []string{"request failed", "request handled"},
+			wantFallback: []string{"starting", "response sent"},
+		},
+	}
+
+	for _, tt := range tests {
+		core := NewRoutingCore(tt.mode, routes, fallback)
+		assert.Equal(t, DebugLevel, LevelOf(core), "Unexpected level.")
+
+		routeEntry(core, Entry{Level: InfoLevel, Message: "starting"})
+		routeEntry(core, Entry{Level: ErrorLevel, LoggerName: "http.server", Message: "request failed"})
+		routeEntry(core, Entry{Level: InfoLevel, LoggerName: "http.server", Message: "request handled"})
+		// Matches a route whose Core drops it, so it isn't sent to the
+		// fallback.
+		routeEntry(core, Entry{Level: DebugLevel, LoggerName: "http.server", Message: "request handled"})
+		routeEntry(core, Entry{Level: InfoLevel, LoggerName: "http.server", Message: "response sent"})
+
+		assert.Equal(t, tt.wantErrors, messages(errorLogs), "Unexpected errors in mode %v.", tt.mode)
+		assert.Equal(t, tt.wantHTTP, messages(httpLogs), "Unexpected HTTP entries in mode %v.", tt.mode)
+		assert.Equal(t, tt.wantFallback, messages(fallbackLogs), "Unexpected fallback entries in mode %v.", tt.mode)
+		errorLogs.TakeAll()
+		httpLogs.TakeAll()
+		fallbackLogs.TakeAll()
+	}
+}
+
+func TestRoutingCoreFields(t *testing.T) {
+	audit, auditLogs := observer.New(DebugLevel)
+	acme, acmeLogs := observer.New(DebugLevel)
+	rest, restLogs := observer.New(DebugLevel)
+	core := NewRoutingCore(RouteFirstMatch, []Route{
+		{Rule: RouteRule{Fields: map[string]string{"audit": "*"}}, Core: audit},
+		{Rule: RouteRule{Fields: map[string]string{"tenant": "acme*", "retries": "[0-2]"}}, Core: acme},
+	}, rest)
+
+	routeEntry(core, Entry{Message: "login"}, Field{Key: "audit", Type: BoolType, Integer: 1})
+	routeEntry(core, Entry{Message: "untagged"})
+
+	tenant := core.With([]Field{{Key: "tenant", Type: StringType, String: "acme-eu"}})
+	routeEntry(tenant, Entry{Message: "retried"}, Field{Key: "retries", Type: Int64Type, Integer: 2})
+	routeEntry(tenant, Entry{Message: "too many retries"}, Field{Key: "retries", Type: Int64Type, Integer: 5})
+	routeEntry(tenant, Entry{Message: "other tenant"},
+		Field{Key: "tenant", Type: StringType, String: "globex"},
+		Field{Key: "retries", Type: Int64Type, Integer: 1},
+	)
+	routeEntry(tenant, Entry{Message: "not a string"}, Field{Key: "retries", Type: ReflectType, Interface: []int{1}})
+
+	assert.Equal(t, []string{"login"}, messages(auditLogs), "Unexpected audit entries.")
+	assert.Equal(t, []string{"retried"}, messages(acmeLogs), "Unexpected acme entries.")
+	assert.Equal(t, []string{"untagged", "too many retries", "other tenant", "not a string"}, messages(restLogs),
+		"Unexpected fallback entries.")
+	assert.Equal(t, "acme-eu", acmeLogs.AllUntimed()[0].ContextMap()["tenant"], "Expected context to be kept.")
+}
+
+func TestRoutingCoreFieldsChecksRouteCores(t *testing.T) {
+	sampled, sampledLogs := observer.New(DebugLevel)
+	debugCore, debugLogs := observer.New(DebugLevel)
+	infoCore, infoLogs := observer.New(InfoLevel)
+	core := NewRoutingCore(RouteFirstMatch, []Route{
+		{
+			Rule: RouteRule{Fields: map[string]string{"audit": "*"}},
+			Core: NewSamplerWithOptions(sampled, time.Hour, 1, 0),
+		},
+	}, NewTee(debugCore, infoCore))
+
+	audit := Field{Key: "audit", Type: BoolType, Integer: 1}
+	routeEntry(core, Entry{Level: InfoLevel, Message: "login", Time: time.Now()}, audit)
+	routeEntry(core, Entry{Level: InfoLevel, Message: "login", Time: time.Now()}, audit)
+	routeEntry(core, Entry{Level: DebugLevel, Message: "debug"})
+	routeEntry(core, Entry{Level: InfoLevel, Message: "info"})
+
+	assert.Equal(t, []string{"login"}, messages(sampledLogs), "Expected the route's sampler to apply.")
+	assert.Equal(t, []string{"debug", "info"}, messages(debugLogs), "Unexpected entries in the debug Core.")
+	assert.Equal(t, []string{"info"}, messages(infoLogs), "Expected the fallback Tee to filter entries by level.")
+}
+
+func TestRoutingCoreEnabled(t *testing.T) {
+	warn, _ := observer.New(WarnLevel)
+	info, _ := observer.New(InfoLevel)
+
+	core := NewRoutingCore(RouteFirstMatch, []Route{
+		{Rule: RouteRule{Level: ErrorLevel}, Core: info},
+	}, warn)
+	assert.False(t, core.Enabled(InfoLevel), "Expected routes' rules to restrict levels.")
+	assert.True(t, core.Enabled(WarnLevel), "Expected the fallback's level to be enabled.")
+	assert.True(t, core.Enabled(ErrorLevel), "Expected the route's level to be enabled.")
+
+	dropping := NewRoutingCore(RouteAllMatches, nil, nil)
+	assert.False(t, dropping.Enabled(FatalLevel), "Expected no levels enabled without routes.")
+	routeEntry(dropping, Entry{Level: FatalLevel})
+	assert.NoError(t, dropping.Sync(), "Unexpected error syncing.")
+}
+
+func TestRoutingCoreErrors(t *testing.T) {
+	failing := &failingCore{err: errors.New("fail")}
+	core := NewRoutingCore(RouteAllMatches, []Route{
+		{Rule: RouteRule{Fields: map[string]string{"k": "*"}}, Core: failing},
+	}, failing)
+
+	ent := Entry{Level: InfoLevel, Time: time.Now()}
+	assert.Error(t, core.Write(ent, []Field{{Key: "k", Type: StringType}}), "Expected route errors to propagate.")
+	assert.Error(t, core.Write(ent, nil), "Expected fallback errors to propagate.")
+}
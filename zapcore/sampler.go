@@ -23,6 +23,8 @@ package zapcore
 import (
 	"sync/atomic"
 	"time"
+
+	"go.uber.org/multierr"
 )
 
 const (
@@ -124,6 +126,57 @@ func SamplerHook(hook func(entry Entry, dec SamplingDecision)) SamplerOption {
 	})
 }
 
+// SamplerLevel overrides the first and thereafter arguments of
+// NewSamplerWithOptions for entries at lvl. For example, to sample debug
+// logs more aggressively than the rest:
+//
+//	zapcore.NewSamplerWithOptions(core, time.Second, 100, 100,
+//	  zapcore.SamplerLevel(zapcore.DebugLevel, 10, 1000))
+func SamplerLevel(lvl Level, first, thereafter int) SamplerOption {
+	return optionFunc(func(s *sampler) {
+		if lvl >= _minLevel && lvl <= _maxLevel {
+			s.rates[lvl-_minLevel] = samplingRate{first: uint64(first), thereafter: uint64(thereafter)}
+		}
+	})
+}
+
+// SamplerExempt exempts entries at the levels enabled by enab from sampling,
+// so that they're never dropped. For example, SamplerExempt(ErrorLevel)
+// keeps every error.
+func SamplerExempt(enab LevelEnabler) SamplerOption {
+	return optionFunc(func(s *sampler) {
+		s.exempt = enab
+	})
+}
+
+// SamplerKeyFields makes fields for which include returns true part of the
+// sampling key, in addition to the level and message. Entries with the same
+// message but different values for these fields are then sampled
+// separately. For example, to sample each tenant's logs on their own:
+//
+//	zapcore.SamplerKeyFields(func(f zapcore.Field) bool {
+//	  return f.Key == "tenant"
+//	})
+//
+// Both fields added with With and fields passed at the log site are
+// considered. The latter are only known once an entry is written, so with
+// this option, sampling decisions are made in Write instead of Check, after
+// the entry's fields have been collected.
+func SamplerKeyFields(include func(Field) bool) SamplerOption {
+	return optionFunc(func(s *sampler) {
+		s.keyFields = include
+	})
+}
+
+// SamplerClock sets the Clock used to timestamp the summaries written by
+// Sync when the SamplerSummary option is in use. Sampling decisions use the
+// time of each entry. It defaults to DefaultClock.
+func SamplerClock(clock Clock) SamplerOption {
+	return optionFunc(func(s *sampler) {
+		s.clock = clock
+	})
+}
+
 // NewSamplerWithOptions creates a Core that samples incoming entries, which
 // caps the CPU and I/O load of logging while attempting to preserve a
 // representative subset of your logs.
@@ -144,23 +197,35 @@ func SamplerHook(hook func(entry Entry, dec SamplingDecision)) SamplerOption {
 // in that interval.
 //
 // Sampler can be configured to report sampling decisions with the SamplerHook
-// option.
+// option. The SamplerLevel and SamplerExempt options tune sampling for
+// individual levels, SamplerKeyFields samples entries separately based on
+// the values of their fields, and SamplerTraceField keeps or drops the
+// entries of a trace or request together. SamplerStats counts the sampler's
+// decisions, and SamplerSummary logs what it dropped.
 //
 // Keep in mind that Zap's sampling implementation is optimized for speed over
 // absolute precision; under load, each tick may be slightly over- or
 // under-sampled.
 func NewSamplerWithOptions(core Core, tick time.Duration, first, thereafter int, opts ...SamplerOption) Core {
 	s := &sampler{
-		Core:       core,
-		tick:       tick,
-		counts:     newCounters(),
-		first:      uint64(first),
-		thereafter: uint64(thereafter),
-		hook:       nopSamplingHook,
+		Core:   core,
+		tick:   tick,
+		counts: newCounters(),
+		hook:   nopSamplingHook,
+		clock:  DefaultClock,
+	}
+	for i := range s.rates {
+		s.rates[i] = samplingRate{first: uint64(first), thereafter: uint64(thereafter)}
 	}
 	for _, opt := range opts {
 		opt.apply(s)
 	}
+	if s.summary != nil {
+		s.summary.core = core
+	}
+	if s.traces != nil && s.traceLimit > 0 {
+		s.traces.limit = s.traceLimit
+	}
 
 	return s
 }
@@ -168,10 +233,23 @@ func NewSamplerWithOptions(core Core, tick time.Duration, first, thereafter int,
 type sampler struct {
 	Core
 
-	counts            *counters
-	tick              time.Duration
+	counts     *counters
+	tick       time.Duration
+	rates      [_numLevels]samplingRate
+	exempt     LevelEnabler     // nil if no levels are exempt
+	keyFields  func(Field) bool // nil to key on the level and message only
+	ctxKey     string           // encoded context fields that are part of the key
+	hook       func(Entry, SamplingDecision)
+	clock      Clock
+	stats      *SamplingStats  // nil unless counting decisions
+	summary    *samplerSummary // nil unless summarizing dropped entries
+	traces     *traceDecisions // nil unless sampling by trace
+	traceID    string          // trace ID from context fields, if any
+	traceLimit int             // overrides the default limit of traces, if set
+}
+
+type samplingRate struct {
 	first, thereafter uint64
-	hook              func(Entry, SamplingDecision)
 }
 
 var (
@@ -201,14 +279,17 @@ func (s *sampler) Level() Level {
 }
 
 func (s *sampler) With(fields []Field) Core {
-	return &sampler{
-		Core:       s.Core.With(fields),
-		tick:       s.tick,
-		counts:     s.counts,
-		first:      s.first,
-		thereafter: s.thereafter,
-		hook:       s.hook,
+	clone := *s
+	clone.Core = s.Core.With(fields)
+	if s.keyFields != nil {
+		clone.ctxKey = s.ctxKey + s.encodeKeyFields(fields)
 	}
+	if s.traces != nil {
+		if id, ok := s.traces.idOf(fields); ok {
+			clone.traceID = id
+		}
+	}
+	return &clone
 }
 
 func (s *sampler) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
@@ -216,14 +297,120 @@ func (s *sampler) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
 		return ce
 	}
 
-	if ent.Level >= _minLevel && ent.Level <= _maxLevel {
-		counter := s.counts.get(ent.Level, ent.Message)
-		n := counter.IncCheckReset(ent.Time, s.tick)
-		if n > s.first && (s.thereafter == 0 || (n-s.first)%s.thereafter != 0) {
-			s.hook(ent, LogDropped)
+	if s.sampled(ent.Level) {
+		if s.keyFields != nil || (s.traces != nil && s.traceID == "") {
+			// The key or trace ID depends on fields that are only known in
+			// Write.
+			return ce.AddCore(ent, s)
+		}
+		if !s.sample(ent, s.traceID, nil) {
 			return ce
 		}
-		s.hook(ent, LogSampled)
 	}
 	return s.Core.Check(ent, ce)
 }
+
+func (s *sampler) Write(ent Entry, fields []Field) error {
+	if (s.keyFields != nil || s.traces != nil) && s.sampled(ent.Level) {
+		traceID := s.traceID
+		if traceID == "" && s.traces != nil {
+			traceID, _ = s.traces.idOf(fields)
+		}
+		if !s.sample(ent, traceID, fields) {
+			return nil
+		}
+		// Check only added the sampler, so let the wrapped Core filter the
+		// entry now.
+		return checkAndWrite(s.Core, ent, fields)
+	}
+	return s.Core.Write(ent, fields)
+}
+
+// sampled reports whether entries at lvl are subject to sampling.
+func (s *sampler) sampled(lvl Level) bool {
+	if lvl < _minLevel || lvl > _maxLevel {
+		return false
+	}
+	return s.exempt == nil || !s.exempt.Enabled(lvl)
+}
+
+// sample decides whether to log an entry, reports the decision, and returns
+// true if the entry should be logged. Entries in a trace get the decision
+// made for the first entry in the trace.
+func (s *sampler) sample(ent Entry, traceID string, fields []Field) bool {
+	var dec SamplingDecision
+	if traceID != "" {
+		dec = s.traces.decide(traceID, ent.Time, func() SamplingDecision {
+			return s.count(ent, fields)
+		})
+	} else {
+		dec = s.count(ent, fields)
+	}
+
+	s.hook(ent, dec)
+	if s.stats != nil {
+		s.stats.counts.record(ent, dec)
+	}
+	if s.summary != nil {
+		s.summary.record(ent, dec)
+	}
+	return dec == LogSampled
+}
+
+// count counts an entry against the limits for its level and sampling key.
+func (s *sampler) count(ent Entry, fields []Field) SamplingDecision {
+	key := ent.Message
+	if s.keyFields != nil {
+		key = s.key(ent, fields)
+	}
+
+	rate := s.rates[ent.Level-_minLevel]
+	n := s.counts.get(ent.Level, key).IncCheckReset(ent.Time, s.tick)
+	if n > rate.first && (rate.thereafter == 0 || (n-rate.first)%rate.thereafter != 0) {
+		return LogDropped
+	}
+	return LogSampled
+}
+
+// Sync writes a summary of the entries dropped since the last one, if the
+// SamplerSummary option is in use, then syncs the wrapped Core.
+func (s *sampler) Sync() error {
+	var err error
+	if s.summary != nil {
+		err = s.summary.flush(s.clock.Now())
+	}
+	return multierr.Append(err, s.Core.Sync())
+}
+
+// key builds the sampling key of an entry from its message and the fields
+// selected by keyFields.
+func (s *sampler) key(ent Entry, fields []Field) string {
+	fieldKey := s.encodeKeyFields(fields)
+	if s.ctxKey == "" && fieldKey == "" {
+		return ent.Message
+	}
+	return ent.Message + "\x00" + s.ctxKey + fieldKey
+}
+
+// encodeKeyFields encodes the fields selected by keyFields.
+func (s *sampler) encodeKeyFields(fields []Field) string {
+	var enc *jsonEncoder
+	for _, f := range fields {
+		if !s.keyFields(f) {
+			continue
+		}
+		if enc == nil {
+			enc = newJSONEncoder(EncoderConfig{}, false)
+		}
+		f.AddTo(enc)
+	}
+	if enc == nil {
+		return ""
+	}
+	// Separate the fields from those of other calls.
+	enc.buf.AppendByte(',')
+	key := enc.buf.String()
+	enc.buf.Free()
+	putJSONEncoder(enc)
+	return key
+}
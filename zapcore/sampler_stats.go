@@ -0,0 +1,280 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// _maxSamplingMessages caps the number of distinct messages counted
+// individually, so that messages with unbounded variety don't exhaust
+// memory. Decisions about other messages are only counted per level.
+const _maxSamplingMessages = 1024
+
+// SamplingCounts counts the decisions made by a sampler.
+type SamplingCounts struct {
+	Sampled uint64
+	Dropped uint64
+}
+
+// MessageSamplingCounts counts the decisions made by a sampler about the
+// entries with a given level and message.
+type MessageSamplingCounts struct {
+	Level   Level
+	Message string
+	SamplingCounts
+}
+
+// SamplingStats counts the decisions made by samplers that were given it
+// with the SamplerStats option. The zero value is ready to use.
+type SamplingStats struct {
+	counts samplingCounts
+}
+
+// SamplerStats makes the sampler count its decisions in stats, per level and
+// per message. Several samplers may share the same SamplingStats.
+//
+//	var stats zapcore.SamplingStats
+//	core = zapcore.NewSamplerWithOptions(core, time.Second, 100, 100, zapcore.SamplerStats(&stats))
+//	...
+//	for _, m := range stats.Messages() {
+//	  fmt.Printf("%v %q: dropped %d\n", m.Level, m.Message, m.Dropped)
+//	}
+//
+// Counting takes a lock, so it adds some overhead to every sampling
+// decision.
+func SamplerStats(stats *SamplingStats) SamplerOption {
+	return optionFunc(func(s *sampler) {
+		s.stats = stats
+	})
+}
+
+// Levels returns the number of entries sampled and dropped at each level.
+// Levels without any decisions are omitted.
+func (s *SamplingStats) Levels() map[Level]SamplingCounts {
+	s.counts.mu.Lock()
+	defer s.counts.mu.Unlock()
+
+	levels := make(map[Level]SamplingCounts)
+	for i, c := range s.counts.levels {
+		if c != (SamplingCounts{}) {
+			levels[Level(i)+_minLevel] = c
+		}
+	}
+	return levels
+}
+
+// Messages returns the number of entries sampled and dropped for each level
+// and message, most dropped first. Only the first 1024 distinct messages
+// are counted.
+func (s *SamplingStats) Messages() []MessageSamplingCounts {
+	s.counts.mu.Lock()
+	defer s.counts.mu.Unlock()
+	return s.counts.messagesLocked()
+}
+
+// Reset sets all counts to zero.
+func (s *SamplingStats) Reset() {
+	s.counts.mu.Lock()
+	defer s.counts.mu.Unlock()
+	s.counts.resetLocked()
+}
+
+// SamplerSummary makes the sampler periodically log a summary of the entries
+// it dropped, at WarnLevel, like
+//
+//	{"level":"warn","msg":"sampler dropped 1500 entries","dropped":1500,"topDropped":[{"level":"info","msg":"request handled","dropped":1200},...]}
+//
+// The summary lists up to top messages that were dropped most often. It's
+// logged once an interval has passed since the previous one, when the
+// sampler next sees an entry, and on Sync. Nothing is logged for intervals
+// without dropped entries. The summary is written to the Core given to
+// NewSamplerWithOptions, without the context added with With.
+func SamplerSummary(interval time.Duration, top int) SamplerOption {
+	return optionFunc(func(s *sampler) {
+		if top < 0 {
+			top = 0
+		}
+		s.summary = &samplerSummary{interval: interval, top: top}
+	})
+}
+
+// samplingCounts holds the counts of SamplingStats and samplerSummary.
+type samplingCounts struct {
+	mu       sync.Mutex
+	levels   [_numLevels]SamplingCounts
+	messages map[messageKey]*SamplingCounts
+}
+
+type messageKey struct {
+	lvl Level
+	msg string
+}
+
+func (c *samplingCounts) record(ent Entry, dec SamplingDecision) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recordLocked(ent, dec)
+}
+
+func (c *samplingCounts) recordLocked(ent Entry, dec SamplingDecision) {
+	lc := &c.levels[ent.Level-_minLevel]
+	mc := c.messages[messageKey{ent.Level, ent.Message}]
+	if mc == nil && len(c.messages) < _maxSamplingMessages {
+		if c.messages == nil {
+			c.messages = make(map[messageKey]*SamplingCounts)
+		}
+		mc = &SamplingCounts{}
+		c.messages[messageKey{ent.Level, ent.Message}] = mc
+	}
+
+	if dec&LogDropped != 0 {
+		lc.Dropped++
+		if mc != nil {
+			mc.Dropped++
+		}
+	}
+	if dec&LogSampled != 0 {
+		lc.Sampled++
+		if mc != nil {
+			mc.Sampled++
+		}
+	}
+}
+
+func (c *samplingCounts) messagesLocked() []MessageSamplingCounts {
+	msgs := make([]MessageSamplingCounts, 0, len(c.messages))
+	for k, mc := range c.messages {
+		msgs = append(msgs, MessageSamplingCounts{Level: k.lvl, Message: k.msg, SamplingCounts: *mc})
+	}
+	sort.Slice(msgs, func(i, j int) bool {
+		a, b := msgs[i], msgs[j]
+		if a.Dropped != b.Dropped {
+			return a.Dropped > b.Dropped
+		}
+		if a.Level != b.Level {
+			return a.Level > b.Level
+		}
+		return a.Message < b.Message
+	})
+	return msgs
+}
+
+func (c *samplingCounts) resetLocked() {
+	c.levels = [_numLevels]SamplingCounts{}
+	c.messages = nil
+}
+
+// samplerSummary is shared by a sampler and the Cores derived from it.
+type samplerSummary struct {
+	core     Core // the Core given to NewSamplerWithOptions
+	interval time.Duration
+	top      int
+
+	counts samplingCounts // guarded by its own lock
+	next   time.Time      // when the next summary is due; guarded by counts.mu
+}
+
+// record counts a decision, and writes a summary if one is due.
+func (s *samplerSummary) record(ent Entry, dec SamplingDecision) {
+	s.counts.mu.Lock()
+	s.counts.recordLocked(ent, dec)
+	if s.next.IsZero() {
+		s.next = ent.Time.Add(s.interval)
+	}
+	if ent.Time.Before(s.next) {
+		s.counts.mu.Unlock()
+		return
+	}
+	s.next = ent.Time.Add(s.interval)
+	ent, fields, ok := s.takeLocked(ent.Time)
+	s.counts.mu.Unlock()
+
+	if ok {
+		writeFromCheck(s.core, ent, fields)
+	}
+}
+
+// flush writes a summary of the entries dropped since the last one.
+func (s *samplerSummary) flush(now time.Time) error {
+	s.counts.mu.Lock()
+	ent, fields, ok := s.takeLocked(now)
+	s.counts.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+	return s.core.Write(ent, fields)
+}
+
+// takeLocked builds a summary entry and resets the counts. It reports false
+// if there's nothing to summarize.
+func (s *samplerSummary) takeLocked(now time.Time) (Entry, []Field, bool) {
+	var dropped uint64
+	for _, c := range s.counts.levels {
+		dropped += c.Dropped
+	}
+	msgs := s.counts.messagesLocked()
+	s.counts.resetLocked()
+	if dropped == 0 || !s.core.Enabled(WarnLevel) {
+		return Entry{}, nil, false
+	}
+
+	// Messages are sorted by dropped count, so those never dropped come last.
+	for i, m := range msgs {
+		if i == s.top || m.Dropped == 0 {
+			msgs = msgs[:i]
+			break
+		}
+	}
+	ent := Entry{
+		Level:   WarnLevel,
+		Time:    now,
+		Message: fmt.Sprintf("sampler dropped %d entries", dropped),
+	}
+	fields := []Field{
+		{Key: "dropped", Type: Uint64Type, Integer: int64(dropped)},
+		{Key: "topDropped", Type: ArrayMarshalerType, Interface: droppedMessages(msgs)},
+	}
+	return ent, fields, true
+}
+
+// droppedMessages lists the messages in a summary.
+type droppedMessages []MessageSamplingCounts
+
+func (ms droppedMessages) MarshalLogArray(enc ArrayEncoder) error {
+	for _, m := range ms {
+		m := m
+		err := enc.AppendObject(ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+			enc.AddString("level", m.Level.String())
+			enc.AddString("msg", m.Message)
+			enc.AddUint64("dropped", m.Dropped)
+			return nil
+		}))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap/internal/ztest"
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func writeAt(core Core, lvl Level, msg string, t time.Time) {
+	if ce := core.Check(Entry{Level: lvl, Message: msg, Time: t}, nil); ce != nil {
+		ce.Write()
+	}
+}
+
+func TestSamplerStats(t *testing.T) {
+	var stats SamplingStats
+	inner, _ := observer.New(DebugLevel)
+	core := NewSamplerWithOptions(inner, time.Minute, 1, 0, SamplerStats(&stats))
+	now := time.Now()
+
+	for i := 0; i < 5; i++ {
+		writeAt(core, InfoLevel, "frequent", now)
+	}
+	writeAt(core.With([]Field{makeInt64Field("k", 1)}), InfoLevel, "frequent", now)
+	writeAt(core, InfoLevel, "rare", now)
+	writeAt(core, ErrorLevel, "failed", now)
+	writeAt(core, ErrorLevel, "failed", now)
+
+	assert.Equal(t, map[Level]SamplingCounts{
+		InfoLevel:  {Sampled: 2, Dropped: 5},
+		ErrorLevel: {Sampled: 1, Dropped: 1},
+	}, stats.Levels(), "Unexpected counts per level.")
+	assert.Equal(t, []MessageSamplingCounts{
+		{Level: InfoLevel, Message: "frequent", SamplingCounts: SamplingCounts{Sampled: 1, Dropped: 5}},
+		{Level: ErrorLevel, Message: "failed", SamplingCounts: SamplingCounts{Sampled: 1, Dropped: 1}},
+		{Level: InfoLevel, Message: "rare", SamplingCounts: SamplingCounts{Sampled: 1}},
+	}, stats.Messages(), "Unexpected counts per message.")
+
+	stats.Reset()
+	assert.Empty(t, stats.Levels(), "Expected Reset to clear counts per level.")
+	assert.Empty(t, stats.Messages(), "Expected Reset to clear counts per message.")
+}
+
+func TestSamplerStatsMessageLimit(t *testing.T) {
+	var stats SamplingStats
+	inner, _ := observer.New(DebugLevel)
+	core := NewSamplerWithOptions(inner, time.Minute, 1, 0, SamplerStats(&stats))
+	now := time.Now()
+
+	for i := 0; i < 2000; i++ {
+		writeAt(core, InfoLevel, fmt.Sprint(i), now)
+	}
+	assert.Len(t, stats.Messages(), 1024, "Expected the number of messages counted to be capped.")
+	// Messages may collide in the sampler's counters, so some are dropped.
+	counts := stats.Levels()[InfoLevel]
+	assert.Equal(t, uint64(2000), counts.Sampled+counts.Dropped, "Expected all decisions to be counted per level.")
+}
+
+func TestSamplerSummary(t *testing.T) {
+	inner, logs := observer.New(DebugLevel)
+	clock := ztest.NewMockClock()
+	core := NewSamplerWithOptions(inner, time.Second, 1, 0, SamplerSummary(time.Minute, 2), SamplerClock(clock))
+	core = core.With([]Field{makeInt64Field("request", 1)})
+	start := time.Now()
+
+	for i := 0; i < 4; i++ {
+		writeAt(core, InfoLevel, "a", start)
+	}
+	for i := 0; i < 3; i++ {
+		writeAt(core, DebugLevel, "b", start)
+	}
+	writeAt(core, WarnLevel, "c", start)
+	writeAt(core, WarnLevel, "c", start)
+	assert.Equal(t, 3, logs.Len(), "Expected no summary before the interval passes.")
+
+	writeAt(core, InfoLevel, "d", start.Add(time.Minute))
+	entries := logs.TakeAll()
+	require.Len(t, entries, 5, "Expected a summary after the interval.")
+	summary := entries[3]
+	assert.Equal(t, WarnLevel, summary.Level, "Unexpected summary level.")
+	assert.Equal(t, start.Add(time.Minute), summary.Time, "Unexpected summary time.")
+	assert.Equal(t, "sampler dropped 6 entries", summary.Message, "Unexpected summary message.")
+	assert.Equal(t, map[string]interface{}{
+		"dropped": uint64(6),
+		"topDropped": []interface{}{
+			map[string]interface{}{"level": "info", "msg": "a", "dropped": uint64(3)},
+			map[string]interface{}{"level": "debug", "msg": "b", "dropped": uint64(2)},
+		},
+	}, summary.ContextMap(), "Expected the top dropped messages without context.")
+	assert.Equal(t, "d", entries[4].Message, "Expected the entry after the summary.")
+
+	writeAt(core, InfoLevel, "e", start.Add(2*time.Minute))
+	assert.Equal(t, 1, logs.Len(), "Expected no summary without dropped entries.")
+	logs.TakeAll()
+
+	writeAt(core, InfoLevel, "e", start.Add(2*time.Minute))
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	entries = logs.TakeAll()
+	require.Len(t, entries, 1, "Expected Sync to write a summary.")
+	assert.Equal(t, "sampler dropped 1 entries", entries[0].Message, "Unexpected summary message.")
+	assert.Equal(t, clock.Now(), entries[0].Time, "Expected Sync to timestamp the summary with the sampler's clock.")
+
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	assert.Zero(t, logs.Len(), "Expected no summary without dropped entries.")
+}
+
+func TestSamplerSummaryDisabledLevel(t *testing.T) {
+	inner, logs := observer.New(ErrorLevel)
+	core := NewSamplerWithOptions(inner, time.Second, 1, 0, SamplerSummary(time.Minute, 10))
+
+	writeAt(core, ErrorLevel, "a", time.Now())
+	writeAt(core, ErrorLevel, "a", time.Now())
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, 1, logs.Len(), "Expected no summary if WarnLevel is disabled.")
+}
@@ -327,3 +327,80 @@ func TestSamplerWithZeroThereafter(t *testing.T) {
 	assert.Equal(t, 4, int(counter.logs.Load()),
 		"Unexpected number of logs")
 }
+
+func TestSamplerLevel(t *testing.T) {
+	core, logs := observer.New(DebugLevel)
+	sampler := NewSamplerWithOptions(core, time.Minute, 2, 3,
+		SamplerLevel(DebugLevel, 1, 0),
+		SamplerExempt(ErrorLevel),
+	)
+
+	for i := 1; i < 10; i++ {
+		for _, lvl := range []Level{DebugLevel, InfoLevel, ErrorLevel, FatalLevel} {
+			writeSequence(sampler, i, lvl)
+		}
+	}
+
+	byLevel := make(map[Level][]observer.LoggedEntry)
+	for _, e := range logs.AllUntimed() {
+		byLevel[e.Level] = append(byLevel[e.Level], e)
+	}
+	assertSequence(t, byLevel[DebugLevel], DebugLevel, 1)
+	assertSequence(t, byLevel[InfoLevel], InfoLevel, 1, 2, 5, 8)
+	assertSequence(t, byLevel[ErrorLevel], ErrorLevel, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	assertSequence(t, byLevel[FatalLevel], FatalLevel, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+}
+
+func TestSamplerKeyFields(t *testing.T) {
+	core, logs := observer.New(DebugLevel)
+	var dropped atomic.Int64
+	sampler := NewSamplerWithOptions(core, time.Minute, 1, 0,
+		SamplerKeyFields(func(f Field) bool { return f.Key == "tenant" }),
+		SamplerHook(func(_ Entry, dec SamplingDecision) {
+			if dec&LogDropped > 0 {
+				dropped.Add(1)
+			}
+		}),
+	)
+	tenant := func(name string) Field {
+		return Field{Key: "tenant", Type: StringType, String: name}
+	}
+	write := func(core Core, fields ...Field) {
+		if ce := core.Check(Entry{Level: InfoLevel, Message: "msg", Time: time.Now()}, nil); ce != nil {
+			ce.Write(fields...)
+		}
+	}
+
+	write(sampler, tenant("a"), makeInt64Field("iter", 1))
+	write(sampler, tenant("a"), makeInt64Field("iter", 2))
+	write(sampler, tenant("b"), makeInt64Field("iter", 3))
+	write(sampler, makeInt64Field("iter", 4))
+	write(sampler, makeInt64Field("iter", 5))
+
+	scoped := sampler.With([]Field{tenant("c")})
+	write(scoped, makeInt64Field("iter", 6))
+	write(scoped, makeInt64Field("iter", 7))
+	write(scoped.With([]Field{makeInt64Field("other", 1)}), makeInt64Field("iter", 8))
+
+	var seen []int64
+	for _, e := range logs.AllUntimed() {
+		seen = append(seen, e.ContextMap()["iter"].(int64))
+	}
+	assert.Equal(t, []int64{1, 3, 4, 6}, seen, "Expected entries to be sampled by tenant.")
+	assert.Equal(t, int64(4), dropped.Load(), "Unexpected number of dropped entries.")
+}
+
+func TestSamplerKeyFieldsChecksWrappedCore(t *testing.T) {
+	debugCore, debugLogs := observer.New(DebugLevel)
+	infoCore, infoLogs := observer.New(InfoLevel)
+	sampler := NewSamplerWithOptions(NewTee(debugCore, infoCore), time.Minute, 10, 0,
+		SamplerKeyFields(func(f Field) bool { return f.Key == "tenant" }))
+
+	for _, lvl := range []Level{DebugLevel, InfoLevel} {
+		if ce := sampler.Check(Entry{Level: lvl, Message: lvl.String(), Time: time.Now()}, nil); ce != nil {
+			ce.Write(Field{Key: "tenant", Type: StringType, String: "a"})
+		}
+	}
+	assert.Equal(t, []string{"debug", "info"}, messages(debugLogs), "Unexpected entries in the debug Core.")
+	assert.Equal(t, []string{"info"}, messages(infoLogs), "Expected the wrapped Tee to filter entries by level.")
+}
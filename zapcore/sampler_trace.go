@@ -0,0 +1,146 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	_defaultTraceWindow = time.Minute
+	_defaultTraceLimit  = 10000
+)
+
+// SamplerTraceField makes the sampler decide once per trace or request
+// whether to log its entries, so that a sampled request keeps all of its
+// log lines. The ID is the value of the field with the given key, added
+// with With or passed at the log site. String, integer, boolean, and
+// duration fields are supported.
+//
+// The first entry with a new ID is sampled as usual, and every later entry
+// with the same ID gets the same decision without being counted. Entries
+// without an ID are sampled as usual. A decision is forgotten once no entry
+// has had its ID for window, which defaults to one minute if zero. At most
+// 10,000 decisions are remembered, after which the least recently used are
+// forgotten early; SamplerTraceLimit changes the limit.
+//
+// Fields added by context extractors, such as those of zap.TraceFields used
+// with zap.WithContextExtractor, are passed to the sampler like any others,
+// so the ID may also come from a context.Context:
+//
+//	logger := zap.New(
+//	  zapcore.NewSamplerWithOptions(core, time.Second, 10, 100, zapcore.SamplerTraceField("trace_id", 0)),
+//	  zap.WithContextExtractor(zap.TraceFields(spanContext, zap.TraceKeys{})),
+//	)
+//	logger.Ctx(ctx).Info("handled request")
+//
+// Unless the ID was added with With, decisions are made in Write instead of
+// Check, after the entry's fields have been collected. Use SamplerExempt to
+// log errors in dropped traces.
+func SamplerTraceField(key string, window time.Duration) SamplerOption {
+	return optionFunc(func(s *sampler) {
+		if window <= 0 {
+			window = _defaultTraceWindow
+		}
+		s.traces = &traceDecisions{
+			key:       key,
+			window:    window,
+			limit:     _defaultTraceLimit,
+			decisions: make(map[string]*list.Element),
+			order:     list.New(),
+		}
+	})
+}
+
+// SamplerTraceLimit sets the number of trace decisions that
+// SamplerTraceField remembers. Once the limit is reached, the decision used
+// least recently is forgotten to make room for a new one. It defaults to
+// 10,000, and has no effect without SamplerTraceField.
+func SamplerTraceLimit(n int) SamplerOption {
+	return optionFunc(func(s *sampler) {
+		if n > 0 {
+			s.traceLimit = n
+		}
+	})
+}
+
+// traceDecisions remembers the sampling decisions made for traces. It's
+// shared by a sampler and the Cores derived from it.
+type traceDecisions struct {
+	key    string
+	window time.Duration
+	limit  int
+
+	mu        sync.Mutex
+	decisions map[string]*list.Element // values are *traceDecision
+	order     *list.List               // most recently used first
+}
+
+type traceDecision struct {
+	id      string
+	dec     SamplingDecision
+	expires time.Time
+}
+
+// idOf returns the trace ID in fields, preferring the last one.
+func (t *traceDecisions) idOf(fields []Field) (string, bool) {
+	for i := len(fields) - 1; i >= 0; i-- {
+		if f := fields[i]; f.Key == t.key {
+			if id, ok := fieldValueString(f); ok && id != "" {
+				return id, true
+			}
+		}
+	}
+	return "", false
+}
+
+// decide returns the decision made for the trace, calling first to make one
+// if there isn't one yet.
+func (t *traceDecisions) decide(id string, now time.Time, first func() SamplingDecision) SamplingDecision {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Each use extends a decision's window, so decisions expire in the
+	// order they were last used, and the expired ones are at the back.
+	for el := t.order.Back(); el != nil && !now.Before(el.Value.(*traceDecision).expires); el = t.order.Back() {
+		t.removeLocked(el)
+	}
+
+	if el, ok := t.decisions[id]; ok {
+		d := el.Value.(*traceDecision)
+		d.expires = now.Add(t.window)
+		t.order.MoveToFront(el)
+		return d.dec
+	}
+	dec := first()
+	if t.order.Len() >= t.limit {
+		t.removeLocked(t.order.Back())
+	}
+	t.decisions[id] = t.order.PushFront(&traceDecision{id: id, dec: dec, expires: now.Add(t.window)})
+	return dec
+}
+
+func (t *traceDecisions) removeLocked(el *list.Element) {
+	t.order.Remove(el)
+	delete(t.decisions, el.Value.(*traceDecision).id)
+}
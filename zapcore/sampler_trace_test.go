@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapcore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	//revive:disable:dot-imports
+	. "go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func traceIDs(logs *observer.ObservedLogs) []string {
+	var ids []string
+	for _, e := range logs.AllUntimed() {
+		id, _ := e.ContextMap()["trace"].(string)
+		ids = append(ids, id+"/"+e.Message)
+	}
+	return ids
+}
+
+func TestSamplerTraceField(t *testing.T) {
+	inner, logs := observer.New(DebugLevel)
+	var decisions []SamplingDecision
+	sampler := NewSamplerWithOptions(inner, time.Minute, 1, 0,
+		SamplerTraceField("trace", 0),
+		SamplerExempt(ErrorLevel),
+		SamplerHook(func(_ Entry, dec SamplingDecision) {
+			decisions = append(decisions, dec)
+		}),
+	)
+	trace := func(id string) Field {
+		return Field{Key: "trace", Type: StringType, String: id}
+	}
+	now := time.Now()
+	write := func(core Core, lvl Level, msg string, fields ...Field) {
+		if ce := core.Check(Entry{Level: lvl, Message: msg, Time: now}, nil); ce != nil {
+			ce.Write(fields...)
+		}
+	}
+
+	// The first request is sampled and keeps every entry, even though the
+	// sampler would otherwise drop repeated messages.
+	a := sampler.With([]Field{trace("a")})
+	write(a, InfoLevel, "start")
+	write(a, DebugLevel, "step")
+	write(a, DebugLevel, "step")
+
+	// The second request's first entry is dropped, and so is the rest of it,
+	// except for errors.
+	b := sampler.With([]Field{trace("b")})
+	write(b, InfoLevel, "start")
+	write(b, DebugLevel, "other")
+	write(b, ErrorLevel, "failed")
+
+	// IDs may also be passed at the log site.
+	write(sampler, InfoLevel, "late", trace("a"))
+	write(sampler, InfoLevel, "late", trace("b"))
+
+	// Entries without an ID are sampled as usual.
+	write(sampler, WarnLevel, "untraced")
+	write(sampler, WarnLevel, "untraced")
+
+	assert.Equal(t, []string{
+		"a/start", "a/step", "a/step", "b/failed", "a/late", "/untraced",
+	}, traceIDs(logs), "Unexpected entries.")
+	assert.Equal(t, []SamplingDecision{
+		LogSampled, LogSampled, LogSampled,
+		LogDropped, LogDropped,
+		LogSampled, LogDropped,
+		LogSampled, LogDropped,
+	}, decisions, "Expected every decision to be reported.")
+}
+
+func TestSamplerTraceFieldWindow(t *testing.T) {
+	inner, logs := observer.New(DebugLevel)
+	sampler := NewSamplerWithOptions(inner, time.Hour, 1, 0, SamplerTraceField("trace", time.Minute))
+	start := time.Now()
+	write := func(id int64, msg string, offset time.Duration) {
+		core := sampler.With([]Field{{Key: "trace", Type: Int64Type, Integer: id}})
+		if ce := core.Check(Entry{Level: InfoLevel, Message: msg, Time: start.Add(offset)}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	write(1, "a", 0)
+	write(2, "a", 0) // dropped
+	write(1, "b", 30*time.Second)
+	write(1, "c", 80*time.Second) // within a minute of the previous entry
+	write(2, "b", 90*time.Second) // a new decision for trace 2, sampled since "b" is new
+	write(2, "c", 100*time.Second)
+	write(1, "d", 3*time.Minute) // a new decision for trace 1
+	write(1, "e", 3*time.Minute)
+
+	var msgs []string
+	for _, e := range logs.AllUntimed() {
+		msgs = append(msgs, e.Message)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "b", "c", "d", "e"}, msgs, "Expected decisions to expire after the window.")
+}
+
+func TestSamplerTraceFieldLimit(t *testing.T) {
+	inner, logs := observer.New(DebugLevel)
+	sampler := NewSamplerWithOptions(inner, time.Hour, 1, 0,
+		SamplerTraceField("trace", 0),
+		SamplerTraceLimit(2),
+	)
+	now := time.Now()
+	write := func(id, msg string) {
+		core := sampler.With([]Field{{Key: "trace", Type: StringType, String: id}})
+		if ce := core.Check(Entry{Level: InfoLevel, Message: msg, Time: now}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	write("2", "x")
+	write("1", "x") // dropped
+	write("2", "a") // makes trace 1 the least recently used
+	write("3", "y") // forgets trace 1
+	write("1", "z") // a new decision, sampled since "z" is new
+
+	assert.Equal(t, []string{"2/x", "2/a", "3/y", "1/z"}, traceIDs(logs),
+		"Expected the least recently used decision to be forgotten.")
+}
+
+func TestSamplerTraceFieldChecksWrappedCore(t *testing.T) {
+	debugCore, debugLogs := observer.New(DebugLevel)
+	infoCore, infoLogs := observer.New(InfoLevel)
+	sampler := NewSamplerWithOptions(NewTee(debugCore, infoCore), time.Minute, 10, 0,
+		SamplerTraceField("trace", 0))
+
+	for _, lvl := range []Level{DebugLevel, InfoLevel} {
+		if ce := sampler.Check(Entry{Level: lvl, Message: lvl.String(), Time: time.Now()}, nil); ce != nil {
+			ce.Write(Field{Key: "trace", Type: StringType, String: "a"})
+		}
+	}
+	assert.Equal(t, []string{"debug", "info"}, messages(debugLogs), "Unexpected entries in the debug Core.")
+	assert.Equal(t, []string{"info"}, messages(infoLogs), "Expected the wrapped Tee to filter entries by level.")
+}
@@ -34,6 +34,27 @@ type WriteSyncer interface {
 	Sync() error
 }
 
+// A LevelWriter is a WriteSyncer that also wants to know the level of each
+// log entry written to it, for example to map it onto a severity understood
+// by the destination. Cores created with NewCore call WriteLevel instead of
+// Write on outputs that implement it, and the WriteSyncers returned by Lock
+// and NewMultiWriteSyncer pass the level through to the WriteSyncers they
+// wrap.
+type LevelWriter interface {
+	WriteSyncer
+
+	// WriteLevel writes a single encoded entry logged at the given level.
+	WriteLevel(Level, []byte) (int, error)
+}
+
+// writeLevel writes p to w, supplying lvl if w is a LevelWriter.
+func writeLevel(w WriteSyncer, lvl Level, p []byte) (int, error) {
+	if lw, ok := w.(LevelWriter); ok {
+		return lw.WriteLevel(lvl, p)
+	}
+	return w.Write(p)
+}
+
 // AddSync converts an io.Writer to a WriteSyncer. It attempts to be
 // intelligent: if the concrete type of the io.Writer implements WriteSyncer,
 // we'll use the existing Sync method. If it doesn't, we'll add a no-op Sync.
@@ -46,6 +67,11 @@ func AddSync(w io.Writer) WriteSyncer {
 	}
 }
 
+var (
+	_ LevelWriter = (*lockedWriteSyncer)(nil)
+	_ LevelWriter = multiWriteSyncer(nil)
+)
+
 type lockedWriteSyncer struct {
 	sync.Mutex
 	ws WriteSyncer
@@ -68,6 +94,13 @@ func (s *lockedWriteSyncer) Write(bs []byte) (int, error) {
 	return n, err
 }
 
+func (s *lockedWriteSyncer) WriteLevel(lvl Level, bs []byte) (int, error) {
+	s.Lock()
+	n, err := writeLevel(s.ws, lvl, bs)
+	s.Unlock()
+	return n, err
+}
+
 func (s *lockedWriteSyncer) Sync() error {
 	s.Lock()
 	err := s.ws.Sync()
@@ -99,10 +132,22 @@ func NewMultiWriteSyncer(ws ...WriteSyncer) WriteSyncer {
 // the smallest number is returned even though Write() is called on
 // all of them.
 func (ws multiWriteSyncer) Write(p []byte) (int, error) {
+	return ws.write(func(w WriteSyncer) (int, error) {
+		return w.Write(p)
+	})
+}
+
+func (ws multiWriteSyncer) WriteLevel(lvl Level, p []byte) (int, error) {
+	return ws.write(func(w WriteSyncer) (int, error) {
+		return writeLevel(w, lvl, p)
+	})
+}
+
+func (ws multiWriteSyncer) write(writeTo func(WriteSyncer) (int, error)) (int, error) {
 	var writeErr error
 	nWritten := 0
 	for _, w := range ws {
-		n, err := w.Write(p)
+		n, err := writeTo(w)
 		writeErr = multierr.Append(writeErr, err)
 		if nWritten == 0 && n != 0 {
 			nWritten = n
@@ -134,3 +134,41 @@ func TestMultiWriteSyncerSync_AllCalled(t *testing.T) {
 	assert.True(t, failed.Called(), "Expected first sink to have Sync method called.")
 	assert.True(t, second.Called(), "Expected call to Sync even with first failure.")
 }
+
+type levelWriteSpy struct {
+	ztest.Buffer
+
+	levels []Level
+}
+
+func (s *levelWriteSpy) WriteLevel(lvl Level, p []byte) (int, error) {
+	s.levels = append(s.levels, lvl)
+	return s.Write(p)
+}
+
+func TestLevelWriterPassthrough(t *testing.T) {
+	spy, plain := &levelWriteSpy{}, &ztest.Buffer{}
+	ws := Lock(NewMultiWriteSyncer(spy, plain))
+
+	lw, ok := ws.(LevelWriter)
+	require.True(t, ok, "Expected locked multi-WriteSyncer to implement LevelWriter.")
+
+	n, err := lw.WriteLevel(WarnLevel, []byte("foo"))
+	require.NoError(t, err, "Unexpected error writing with level.")
+	assert.Equal(t, 3, n, "Unexpected number of bytes written.")
+
+	assert.Equal(t, []Level{WarnLevel}, spy.levels, "Expected level to reach the LevelWriter.")
+	assert.Equal(t, "foo", spy.String(), "Unexpected output from LevelWriter.")
+	assert.Equal(t, "foo", plain.String(), "Expected plain WriteSyncer to receive the write.")
+}
+
+func TestIOCoreWritesLevel(t *testing.T) {
+	spy := &levelWriteSpy{}
+	core := NewCore(NewJSONEncoder(EncoderConfig{MessageKey: "msg"}), Lock(spy), DebugLevel)
+
+	for _, lvl := range []Level{DebugLevel, ErrorLevel} {
+		require.NoError(t, core.Write(Entry{Level: lvl, Message: lvl.String()}, nil), "Unexpected error writing entry.")
+	}
+	assert.Equal(t, []Level{DebugLevel, ErrorLevel}, spy.levels, "Expected entry levels to reach the output.")
+	assert.Equal(t, []string{`{"msg":"debug"}`, `{"msg":"error"}`}, spy.Lines(), "Unexpected output.")
+}
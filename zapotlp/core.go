@@ -0,0 +1,474 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package zapotlp exports logs in the OpenTelemetry Protocol (OTLP) format,
+// either to a collector over OTLP/HTTP or as OTLP/JSON lines to a
+// WriteSyncer, without re-parsing zap's JSON output.
+package zapotlp
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	_defaultBatchSize     = 512
+	_defaultMaxBuffered   = 8 * _defaultBatchSize
+	_defaultFlushInterval = time.Second
+)
+
+// An Option configures a Core.
+type Option interface {
+	apply(*batcher)
+}
+
+type optionFunc func(*batcher)
+
+func (f optionFunc) apply(b *batcher) {
+	f(b)
+}
+
+// BatchSize sets the number of records the Core buffers before exporting
+// them. It defaults to 512.
+func BatchSize(n int) Option {
+	return optionFunc(func(b *batcher) {
+		if n > 0 {
+			b.batchSize = n
+		}
+	})
+}
+
+// MaxBuffered sets the number of records the Core holds on to while exports
+// fail. Records from failed exports are retried with the next export; once
+// the limit is reached, the oldest records are dropped, and the next Sync
+// reports how many. It defaults to eight batches of the default size.
+func MaxBuffered(n int) Option {
+	return optionFunc(func(b *batcher) {
+		if n > 0 {
+			b.maxBuffered = n
+		}
+	})
+}
+
+// FlushInterval sets how often the Core exports buffered records in the
+// background, however few there are. It also sets how long the Core waits
+// before retrying a failed export of a full batch. It defaults to one
+// second. Zero disables periodic exports, so that records are only exported
+// when a batch is full and on Sync.
+func FlushInterval(d time.Duration) Option {
+	return optionFunc(func(b *batcher) {
+		b.interval = d
+	})
+}
+
+// Resource sets the attributes of the resource producing the logs, such as
+// zap.String("service.name", "payments").
+func Resource(fields ...zapcore.Field) Option {
+	return optionFunc(func(b *batcher) {
+		enc := newAttrEncoder()
+		for _, f := range fields {
+			f.AddTo(enc)
+		}
+		b.resource = enc.root.Values
+	})
+}
+
+// WithClock sets the clock used for the observed time of records and for
+// background exports. It defaults to zapcore.DefaultClock.
+func WithClock(clock zapcore.Clock) Option {
+	return optionFunc(func(b *batcher) {
+		b.clock = clock
+	})
+}
+
+// ErrorOutput sets where errors from background exports are reported. It
+// defaults to standard error.
+func ErrorOutput(ws zapcore.WriteSyncer) Option {
+	return optionFunc(func(b *batcher) {
+		b.errorOutput = ws
+	})
+}
+
+// WithTraceKeys sets the keys of the fields holding trace and span IDs,
+// such as those added by zap.TraceFields. Top-level string fields with
+// these keys and valid hex IDs populate the record's trace context instead
+// of its attributes. The keys default to those of zap.TraceFields.
+func WithTraceKeys(keys zap.TraceKeys) Option {
+	return optionFunc(func(b *batcher) {
+		if keys.TraceID != "" {
+			b.traceKeys.TraceID = keys.TraceID
+		}
+		if keys.SpanID != "" {
+			b.traceKeys.SpanID = keys.SpanID
+		}
+		if keys.TraceFlags != "" {
+			b.traceKeys.TraceFlags = keys.TraceFlags
+		}
+	})
+}
+
+// A Core converts entries to OTLP log records and exports them in batches.
+//
+// Each record's severity is derived from the entry's level, its body is
+// the message, and its fields become attributes. The logger name is used
+// as the instrumentation scope. Callers and stack traces are recorded with
+// the code.filepath, code.lineno, code.function, and code.stacktrace
+// attributes.
+//
+// Records are exported in the background when a batch is full and when the
+// flush interval passes. Entries above ErrorLevel and Sync export buffered
+// records on the calling goroutine. Call Stop to export buffered records and
+// stop the background goroutine.
+type Core struct {
+	zapcore.LevelEnabler
+
+	attrs *attrEncoder
+	b     *batcher
+}
+
+var _ zapcore.Core = (*Core)(nil)
+
+// NewCore creates a Core that exports entries enabled by enab with the
+// given Exporter.
+func NewCore(enab zapcore.LevelEnabler, exporter Exporter, opts ...Option) *Core {
+	b := &batcher{
+		exporter:    exporter,
+		batchSize:   _defaultBatchSize,
+		maxBuffered: _defaultMaxBuffered,
+		interval:    _defaultFlushInterval,
+		clock:       zapcore.DefaultClock,
+		errorOutput: zapcore.Lock(os.Stderr),
+		traceKeys:   zap.TraceKeys{TraceID: "trace_id", SpanID: "span_id", TraceFlags: "trace_flags"},
+		full:        make(chan struct{}, 1),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt.apply(b)
+	}
+	if b.maxBuffered < b.batchSize {
+		b.maxBuffered = b.batchSize
+	}
+	var ticker *time.Ticker
+	if b.interval > 0 {
+		// Create the ticker before returning so that it observes all
+		// later changes to the clock.
+		ticker = b.clock.NewTicker(b.interval)
+	}
+	go b.run(ticker)
+	return &Core{LevelEnabler: enab, attrs: newAttrEncoder(), b: b}
+}
+
+// Level returns the minimum level enabled by the Core.
+func (c *Core) Level() zapcore.Level {
+	return zapcore.LevelOf(c.LevelEnabler)
+}
+
+// With adds fields to the attributes of every record written by the
+// returned Core. It shares batches with c.
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	attrs := c.attrs.clone()
+	for _, f := range fields {
+		f.AddTo(attrs)
+	}
+	return &Core{LevelEnabler: c.LevelEnabler, attrs: attrs, b: c.b}
+}
+
+// Check adds the Core to the CheckedEntry if the level is enabled.
+func (c *Core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write converts the entry to a record and adds it to the batch. A full
+// batch is handed to the background goroutine to export, while entries
+// above ErrorLevel are exported before Write returns.
+func (c *Core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	full := c.b.add(c.b.record(ent, c.attrs, fields))
+	if ent.Level > zapcore.ErrorLevel {
+		return c.b.flush()
+	}
+	if full {
+		return c.b.flushFull()
+	}
+	return nil
+}
+
+// Sync exports buffered records, then syncs the Exporter if it has a Sync
+// method.
+func (c *Core) Sync() error {
+	err := c.b.flush()
+	if s, ok := c.b.exporter.(interface{ Sync() error }); ok {
+		err = multierr.Append(err, s.Sync())
+	}
+	return err
+}
+
+// Stop stops the background goroutine and exports buffered records. Records
+// written after Stop are exported when a batch is full, on the writing
+// goroutine, and on Sync. Calling Stop more than once is safe.
+func (c *Core) Stop() error {
+	c.b.stopOnce.Do(func() {
+		close(c.b.stop)
+	})
+	<-c.b.done
+	return c.Sync()
+}
+
+// batcher buffers records for a Core and the Cores derived from it.
+type batcher struct {
+	exporter    Exporter
+	batchSize   int
+	maxBuffered int
+	interval    time.Duration
+	clock       zapcore.Clock
+	resource    []keyValue
+	errorOutput zapcore.WriteSyncer
+	traceKeys   zap.TraceKeys
+
+	mu      sync.Mutex
+	records []logRecord
+	dropped int // records dropped since the last report
+
+	exportMu sync.Mutex // keeps exports in order
+
+	full     chan struct{} // signals the background goroutine that a batch is full
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// record converts an entry and its fields to a log record.
+func (b *batcher) record(ent zapcore.Entry, attrs *attrEncoder, fields []zapcore.Field) logRecord {
+	enc := attrs.clone()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	rec := logRecord{
+		TimeUnixNano:         uint64(ent.Time.UnixNano()),
+		ObservedTimeUnixNano: uint64(b.clock.Now().UnixNano()),
+		SeverityNumber:       severityNumber(ent.Level),
+		SeverityText:         ent.Level.CapitalString(),
+		Body:                 stringValue(ent.Message),
+		scope:                ent.LoggerName,
+	}
+	if ent.Time.IsZero() {
+		rec.TimeUnixNano = 0
+	}
+
+	// Fields added inside namespaces don't carry trace context.
+	root := enc.root.Values[:0:0]
+	for _, kv := range enc.root.Values {
+		if kv.Value.StringValue != nil && b.setTraceContext(&rec, kv.Key, *kv.Value.StringValue) {
+			continue
+		}
+		root = append(root, kv)
+	}
+	enc.root.Values = root
+
+	if ent.Caller.Defined {
+		enc.root.Values = append(enc.root.Values,
+			keyValue{Key: "code.filepath", Value: stringValue(ent.Caller.File)},
+			keyValue{Key: "code.lineno", Value: intValue(int64(ent.Caller.Line))},
+		)
+		if ent.Caller.Function != "" {
+			enc.root.Values = append(enc.root.Values, keyValue{Key: "code.function", Value: stringValue(ent.Caller.Function)})
+		}
+	}
+	if ent.Stack != "" {
+		enc.root.Values = append(enc.root.Values, keyValue{Key: "code.stacktrace", Value: stringValue(ent.Stack)})
+	}
+	rec.Attributes = enc.root.Values
+	return rec
+}
+
+// setTraceContext sets the trace ID, span ID, or flags of the record if key
+// names one of them and val is valid for it.
+func (b *batcher) setTraceContext(rec *logRecord, key, val string) bool {
+	switch key {
+	case b.traceKeys.TraceID:
+		if isHexID(val, 16) {
+			rec.TraceID = val
+			return true
+		}
+	case b.traceKeys.SpanID:
+		if isHexID(val, 8) {
+			rec.SpanID = val
+			return true
+		}
+	case b.traceKeys.TraceFlags:
+		if flags, err := hex.DecodeString(val); err == nil && len(flags) == 1 {
+			rec.Flags = uint32(flags[0])
+			return true
+		}
+	}
+	return false
+}
+
+// add buffers a record and reports whether the batch is full.
+func (b *batcher) add(rec logRecord) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.records = append(b.records, rec)
+	b.trimLocked()
+	return len(b.records) >= b.batchSize
+}
+
+// trimLocked drops the oldest records beyond the buffer limit.
+//
+// b.mu must be held.
+func (b *batcher) trimLocked() {
+	if over := len(b.records) - b.maxBuffered; over > 0 {
+		b.records = b.records[over:]
+		b.dropped += over
+	}
+}
+
+// flushFull hands a full batch to the background goroutine, or exports it
+// directly once the goroutine has stopped.
+func (b *batcher) flushFull() error {
+	select {
+	case <-b.done:
+		return b.flush()
+	default:
+	}
+	select {
+	case b.full <- struct{}{}:
+	default: // already signaled
+	}
+	return nil
+}
+
+// flush exports all buffered records in batches. If an export fails, the
+// records that weren't exported go back to the buffer to be retried.
+func (b *batcher) flush() error {
+	b.exportMu.Lock()
+	defer b.exportMu.Unlock()
+
+	b.mu.Lock()
+	records := b.records
+	b.records = nil
+	b.mu.Unlock()
+
+	var err error
+	for len(records) > 0 {
+		n := b.batchSize
+		if n > len(records) {
+			n = len(records)
+		}
+		request, merr := json.Marshal(b.request(records[:n]))
+		if merr != nil {
+			// Retrying won't help, so drop the batch.
+			err = multierr.Append(err, merr)
+			b.drop(n)
+		} else if err = b.exporter.Export(context.Background(), request); err != nil {
+			break
+		}
+		records = records[n:]
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(records) > 0 {
+		b.records = append(records, b.records...)
+		b.trimLocked()
+	}
+	if b.dropped > 0 {
+		err = multierr.Append(err, fmt.Errorf("dropped %d records", b.dropped))
+		b.dropped = 0
+	}
+	return err
+}
+
+func (b *batcher) drop(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.dropped += n
+}
+
+// request groups records by scope, in the order each scope first appears.
+func (b *batcher) request(records []logRecord) exportLogsServiceRequest {
+	var scopes []scopeLogs
+	index := make(map[string]int)
+	for _, rec := range records {
+		i, ok := index[rec.scope]
+		if !ok {
+			i = len(scopes)
+			index[rec.scope] = i
+			scopes = append(scopes, scopeLogs{Scope: scope{Name: rec.scope}})
+		}
+		scopes[i].LogRecords = append(scopes[i].LogRecords, rec)
+	}
+	return exportLogsServiceRequest{
+		ResourceLogs: []resourceLogs{{
+			Resource:  resource{Attributes: b.resource},
+			ScopeLogs: scopes,
+		}},
+	}
+}
+
+// run exports buffered records every flush interval, if there is one, and
+// whenever a batch is full, until stopped.
+func (b *batcher) run(ticker *time.Ticker) {
+	defer close(b.done)
+
+	var tick <-chan time.Time
+	if ticker != nil {
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	retryDelay := b.interval
+	if retryDelay <= 0 {
+		retryDelay = _defaultFlushInterval
+	}
+	var retryAt time.Time // don't export full batches before this
+	for {
+		select {
+		case <-tick:
+		case <-b.full:
+			if b.clock.Now().Before(retryAt) {
+				continue
+			}
+		case <-b.stop:
+			return
+		}
+		if err := b.flush(); err != nil {
+			retryAt = b.clock.Now().Add(retryDelay)
+			_, _ = fmt.Fprintf(b.errorOutput, "%v zapotlp export error: %v\n", b.clock.Now().UTC(), err)
+			_ = b.errorOutput.Sync()
+		} else {
+			retryAt = time.Time{}
+		}
+	}
+}
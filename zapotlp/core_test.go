@@ -0,0 +1,403 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapotlp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/internal/ztest"
+	"go.uber.org/zap/zapcore"
+)
+
+// collector is an OTLP/HTTP endpoint that records the requests it receives.
+type collector struct {
+	t *testing.T
+
+	mu       sync.Mutex
+	requests []exportLogsServiceRequest
+	headers  []http.Header
+	received chan struct{}
+}
+
+func newCollector(t *testing.T) (*collector, *httptest.Server) {
+	c := &collector{t: t, received: make(chan struct{}, 16)}
+	srv := httptest.NewServer(c)
+	t.Cleanup(srv.Close)
+	return c, srv
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	assert.Equal(c.t, http.MethodPost, r.Method, "Unexpected method.")
+	assert.Equal(c.t, "application/json", r.Header.Get("Content-Type"), "Unexpected content type.")
+
+	var req exportLogsServiceRequest
+	if !assert.NoError(c.t, json.NewDecoder(r.Body).Decode(&req), "Failed to decode request.") {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	c.mu.Lock()
+	c.requests = append(c.requests, req)
+	c.headers = append(c.headers, r.Header.Clone())
+	c.mu.Unlock()
+
+	_, _ = io.WriteString(w, "{}")
+	c.received <- struct{}{}
+}
+
+func (c *collector) Requests() []exportLogsServiceRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]exportLogsServiceRequest(nil), c.requests...)
+}
+
+func attrs(kvs []keyValue) map[string]interface{} {
+	m := make(map[string]interface{}, len(kvs))
+	for _, kv := range kvs {
+		m[kv.Key] = kv.Value.interfaceValue()
+	}
+	return m
+}
+
+// interfaceValue simplifies an anyValue for comparisons in tests.
+func (v anyValue) interfaceValue() interface{} {
+	switch {
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.BoolValue != nil:
+		return *v.BoolValue
+	case v.IntValue != nil:
+		return *v.IntValue
+	case v.DoubleValue != nil:
+		return *v.DoubleValue
+	case v.BytesValue != nil:
+		return v.BytesValue
+	case v.ArrayValue != nil:
+		vals := make([]interface{}, len(v.ArrayValue.Values))
+		for i, val := range v.ArrayValue.Values {
+			vals[i] = val.interfaceValue()
+		}
+		return vals
+	case v.KvlistValue != nil:
+		return attrs(v.KvlistValue.Values)
+	}
+	return nil
+}
+
+func TestCoreHTTP(t *testing.T) {
+	col, srv := newCollector(t)
+	core := NewCore(
+		zapcore.DebugLevel,
+		NewHTTPExporter(srv.URL+"/v1/logs", WithHTTPHeaders(map[string]string{"Authorization": "Bearer token"})),
+		FlushInterval(0),
+		Resource(zap.String("service.name", "payments")),
+	)
+	defer func() { assert.NoError(t, core.Stop(), "Unexpected error stopping core.") }()
+
+	logger := zap.New(core).Named("api").With(zap.String("region", "us-east"))
+	logger.Info("handled request",
+		zap.Int("status", 200),
+		zap.Bool("cached", false),
+		zap.Float64("ratio", 0.5),
+		zap.Duration("elapsed", time.Second),
+		zap.Strings("tags", []string{"a", "b"}),
+		zap.Namespace("user"),
+		zap.String("id", "u1"),
+	)
+	logger.Named("db").Warn("slow query")
+	require.NoError(t, logger.Sync(), "Unexpected error syncing logger.")
+
+	reqs := col.Requests()
+	require.Len(t, reqs, 1, "Expected a single export.")
+	assert.Equal(t, "Bearer token", col.headers[0].Get("Authorization"), "Expected configured headers.")
+
+	require.Len(t, reqs[0].ResourceLogs, 1, "Expected a single resource.")
+	rl := reqs[0].ResourceLogs[0]
+	assert.Equal(t, map[string]interface{}{"service.name": "payments"}, attrs(rl.Resource.Attributes), "Unexpected resource.")
+
+	require.Len(t, rl.ScopeLogs, 2, "Expected records grouped by logger name.")
+	assert.Equal(t, "api", rl.ScopeLogs[0].Scope.Name, "Unexpected scope.")
+	assert.Equal(t, "api.db", rl.ScopeLogs[1].Scope.Name, "Unexpected scope.")
+
+	require.Len(t, rl.ScopeLogs[0].LogRecords, 1, "Unexpected records.")
+	rec := rl.ScopeLogs[0].LogRecords[0]
+	assert.Equal(t, severityInfo, rec.SeverityNumber, "Unexpected severity number.")
+	assert.Equal(t, "INFO", rec.SeverityText, "Unexpected severity text.")
+	assert.Equal(t, "handled request", rec.Body.interfaceValue(), "Unexpected body.")
+	assert.NotZero(t, rec.TimeUnixNano, "Expected a timestamp.")
+	assert.Equal(t, map[string]interface{}{
+		"region":  "us-east",
+		"status":  int64(200),
+		"cached":  false,
+		"ratio":   0.5,
+		"elapsed": "1s",
+		"tags":    []interface{}{"a", "b"},
+		"user":    map[string]interface{}{"id": "u1"},
+	}, attrs(rec.Attributes), "Unexpected attributes.")
+
+	require.Len(t, rl.ScopeLogs[1].LogRecords, 1, "Unexpected records.")
+	rec = rl.ScopeLogs[1].LogRecords[0]
+	assert.Equal(t, severityWarn, rec.SeverityNumber, "Unexpected severity number.")
+	assert.Equal(t, map[string]interface{}{"region": "us-east"}, attrs(rec.Attributes), "Unexpected attributes.")
+}
+
+func TestCoreHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "quota exceeded", http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	core := NewCore(zapcore.InfoLevel, NewHTTPExporter(srv.URL), FlushInterval(0))
+	defer core.Stop()
+
+	logger := zap.New(core)
+	logger.Info("dropped")
+	err := logger.Sync()
+	require.Error(t, err, "Expected an error from the collector.")
+	assert.Contains(t, err.Error(), "429 Too Many Requests", "Expected the status in the error.")
+	assert.Contains(t, err.Error(), "quota exceeded", "Expected the response body in the error.")
+}
+
+func TestCoreWriter(t *testing.T) {
+	var buf ztest.Buffer
+	core := NewCore(zapcore.InfoLevel, NewWriterExporter(&buf), FlushInterval(0), BatchSize(2))
+	defer core.Stop()
+
+	logger := zap.New(core)
+	logger.Debug("disabled")
+	logger.Info("one")
+	assert.Empty(t, buf.Lines(), "Expected records to be buffered.")
+
+	logger.Info("two")
+	logger.Info("three")
+	require.NoError(t, core.Stop(), "Unexpected error stopping core.")
+	require.Len(t, buf.Lines(), 2, "Expected a full batch, then Stop to export buffered records.")
+	assert.True(t, buf.Called(), "Expected Stop to sync the output.")
+
+	var bodies []interface{}
+	for _, line := range buf.Lines() {
+		var req exportLogsServiceRequest
+		require.NoError(t, json.Unmarshal([]byte(line), &req), "Failed to decode line %q.", line)
+		for _, rec := range req.ResourceLogs[0].ScopeLogs[0].LogRecords {
+			bodies = append(bodies, rec.Body.interfaceValue())
+		}
+	}
+	assert.Equal(t, []interface{}{"one", "two", "three"}, bodies, "Unexpected records.")
+}
+
+func TestCoreExportsFullBatchesInBackground(t *testing.T) {
+	release := make(chan struct{})
+	exported := make(chan int, 1)
+	exp := exporterFunc(func(_ context.Context, request []byte) error {
+		<-release
+		exported <- len(request)
+		return nil
+	})
+	core := NewCore(zapcore.InfoLevel, exp, FlushInterval(0), BatchSize(2))
+	defer core.Stop()
+
+	logger := zap.New(core)
+	logger.Info("one")
+	logger.Info("two") // doesn't wait for the blocked exporter
+	close(release)
+	select {
+	case <-exported:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the full batch to be exported in the background.")
+	}
+}
+
+func TestCoreRetriesFailedExports(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		fail   = true
+		bodies []interface{}
+	)
+	exp := exporterFunc(func(_ context.Context, request []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if fail {
+			return errors.New("collector unavailable")
+		}
+		var req exportLogsServiceRequest
+		require.NoError(t, json.Unmarshal(request, &req), "Failed to decode request.")
+		for _, rec := range req.ResourceLogs[0].ScopeLogs[0].LogRecords {
+			bodies = append(bodies, rec.Body.interfaceValue())
+		}
+		return nil
+	})
+	core := NewCore(zapcore.InfoLevel, exp, FlushInterval(0), BatchSize(2), MaxBuffered(3))
+	// Once stopped, the Core exports full batches on the writing goroutine.
+	require.NoError(t, core.Stop(), "Unexpected error stopping core.")
+
+	write := func(msg string) error {
+		return core.Write(zapcore.Entry{Level: zapcore.InfoLevel, Message: msg}, nil)
+	}
+	assert.NoError(t, write("one"), "Expected the record to be buffered.")
+	assert.ErrorContains(t, write("two"), "collector unavailable", "Expected the export error.")
+	assert.ErrorContains(t, write("three"), "collector unavailable", "Expected failed records to be retried.")
+	assert.ErrorContains(t, write("four"), "dropped 1 records", "Expected records beyond the limit to be counted.")
+
+	mu.Lock()
+	fail = false
+	mu.Unlock()
+	require.NoError(t, core.Sync(), "Expected the retry to succeed.")
+	assert.Equal(t, []interface{}{"two", "three", "four"}, bodies, "Expected failed records to be retried in order.")
+}
+
+func TestCoreFlushesOnHighLevels(t *testing.T) {
+	var buf ztest.Buffer
+	core := NewCore(zapcore.InfoLevel, NewWriterExporter(&buf), FlushInterval(0))
+	defer core.Stop()
+
+	logger := zap.New(core)
+	logger.Error("buffered")
+	assert.Empty(t, buf.Lines(), "Expected errors to be buffered.")
+
+	logger.DPanic("exported")
+	assert.Len(t, buf.Lines(), 1, "Expected entries above ErrorLevel to export the batch.")
+}
+
+func TestCoreFlushInterval(t *testing.T) {
+	col, srv := newCollector(t)
+	clock := ztest.NewMockClock()
+	core := NewCore(zapcore.InfoLevel, NewHTTPExporter(srv.URL), WithClock(clock), FlushInterval(time.Minute))
+	defer core.Stop()
+
+	zap.New(core).Info("hello")
+	assert.Empty(t, col.Requests(), "Expected records to be buffered.")
+
+	clock.Add(time.Minute)
+	select {
+	case <-col.received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected records to be exported after the flush interval.")
+	}
+
+	reqs := col.Requests()
+	require.Len(t, reqs, 1, "Expected a single export.")
+	rec := reqs[0].ResourceLogs[0].ScopeLogs[0].LogRecords[0]
+	assert.Equal(t, uint64(clock.Now().Add(-time.Minute).UnixNano()), rec.ObservedTimeUnixNano, "Expected the observed time from the clock.")
+}
+
+func TestCoreFlushIntervalError(t *testing.T) {
+	var errOut ztest.Buffer
+	clock := ztest.NewMockClock()
+	exported := make(chan struct{}, 1)
+	exp := exporterFunc(func(context.Context, []byte) error {
+		select {
+		case exported <- struct{}{}:
+		default:
+		}
+		return errors.New("collector unavailable")
+	})
+	core := NewCore(zapcore.InfoLevel, exp, WithClock(clock), FlushInterval(time.Minute), ErrorOutput(&errOut))
+
+	zap.New(core).Info("hello")
+	clock.Add(time.Minute)
+	<-exported
+	assert.Error(t, core.Stop(), "Expected Stop to retry the failed export.")
+
+	assert.Contains(t, errOut.String(), "zapotlp export error: collector unavailable", "Expected export errors to be reported.")
+}
+
+func TestCoreTraceContext(t *testing.T) {
+	var buf ztest.Buffer
+	core := NewCore(zapcore.InfoLevel, NewWriterExporter(&buf), FlushInterval(0))
+	defer core.Stop()
+
+	spanContext := func(context.Context) (zap.TraceContext, bool) {
+		return zap.TraceContext{
+			TraceID:    [16]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+			SpanID:     [8]byte{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+			TraceFlags: 1,
+		}, true
+	}
+	logger := zap.New(core, zap.WithContextExtractor(zap.TraceFields(spanContext, zap.TraceKeys{})))
+	logger.Ctx(context.Background()).Info("traced")
+	logger.Info("invalid", zap.String("trace_id", "not-hex"), zap.String("span_id", "0000000000000000"))
+	require.NoError(t, core.Sync(), "Unexpected error syncing core.")
+
+	var req exportLogsServiceRequest
+	require.NoError(t, json.Unmarshal([]byte(buf.Lines()[0]), &req), "Failed to decode request.")
+	recs := req.ResourceLogs[0].ScopeLogs[0].LogRecords
+	require.Len(t, recs, 2, "Unexpected records.")
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", recs[0].TraceID, "Unexpected trace ID.")
+	assert.Equal(t, "00f067aa0ba902b7", recs[0].SpanID, "Unexpected span ID.")
+	assert.Equal(t, uint32(1), recs[0].Flags, "Unexpected trace flags.")
+	assert.Empty(t, recs[0].Attributes, "Expected trace fields to be removed from attributes.")
+
+	assert.Empty(t, recs[1].TraceID, "Expected invalid trace IDs to be ignored.")
+	assert.Empty(t, recs[1].SpanID, "Expected invalid span IDs to be ignored.")
+	assert.Equal(t, map[string]interface{}{
+		"trace_id": "not-hex",
+		"span_id":  "0000000000000000",
+	}, attrs(recs[1].Attributes), "Expected invalid trace fields to be kept as attributes.")
+}
+
+func TestCoreCallerAndStack(t *testing.T) {
+	var buf ztest.Buffer
+	core := NewCore(zapcore.InfoLevel, NewWriterExporter(&buf), FlushInterval(0))
+	defer core.Stop()
+
+	zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel)).Error("failed")
+	require.NoError(t, core.Sync(), "Unexpected error syncing core.")
+
+	var req exportLogsServiceRequest
+	require.NoError(t, json.Unmarshal([]byte(buf.Lines()[0]), &req), "Failed to decode request.")
+	got := attrs(req.ResourceLogs[0].ScopeLogs[0].LogRecords[0].Attributes)
+	assert.Regexp(t, `"intValue":"\d+"`, buf.Lines()[0], "Expected 64-bit integers to be encoded as strings.")
+
+	assert.True(t, strings.HasSuffix(got["code.filepath"].(string), "zapotlp/core_test.go"), "Unexpected file: %v", got["code.filepath"])
+	assert.NotZero(t, got["code.lineno"], "Expected a line number.")
+	assert.Equal(t, "go.uber.org/zap/zapotlp.TestCoreCallerAndStack", got["code.function"], "Unexpected function.")
+	assert.Contains(t, got["code.stacktrace"], "TestCoreCallerAndStack", "Expected a stack trace.")
+}
+
+func TestCoreStopIdempotent(t *testing.T) {
+	var buf ztest.Buffer
+	core := NewCore(zapcore.InfoLevel, NewWriterExporter(&buf))
+	assert.Equal(t, zapcore.InfoLevel, core.Level(), "Unexpected level.")
+
+	zap.New(core).Info("hello")
+	assert.NoError(t, core.Stop(), "Unexpected error stopping core.")
+	assert.NoError(t, core.Stop(), "Unexpected error stopping core twice.")
+	assert.Len(t, buf.Lines(), 1, "Expected a single export.")
+}
+
+type exporterFunc func(context.Context, []byte) error
+
+func (f exporterFunc) Export(ctx context.Context, request []byte) error {
+	return f(ctx, request)
+}
@@ -0,0 +1,259 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapotlp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// attrEncoder is a zapcore.ObjectEncoder that builds OTLP attributes.
+//
+// Namespaces nest: each one is added as the last attribute of the one
+// before it, so the attributes that are added to later form a chain from
+// the root, ns levels deep.
+type attrEncoder struct {
+	root *kvlistValue
+	cur  *kvlistValue // innermost open namespace, or root
+	ns   int
+}
+
+var _ zapcore.ObjectEncoder = (*attrEncoder)(nil)
+
+func newAttrEncoder() *attrEncoder {
+	root := &kvlistValue{}
+	return &attrEncoder{root: root, cur: root}
+}
+
+// clone copies the encoder so that adding to the copy doesn't affect the
+// original. Only the chain of open namespaces is copied, since attributes
+// are never added anywhere else.
+func (e *attrEncoder) clone() *attrEncoder {
+	root := &kvlistValue{Values: append([]keyValue(nil), e.root.Values...)}
+	cur := root
+	for i := 0; i < e.ns; i++ {
+		last := &cur.Values[len(cur.Values)-1]
+		child := &kvlistValue{Values: append([]keyValue(nil), last.Value.KvlistValue.Values...)}
+		last.Value.KvlistValue = child
+		cur = child
+	}
+	return &attrEncoder{root: root, cur: cur, ns: e.ns}
+}
+
+func (e *attrEncoder) add(key string, v anyValue) {
+	e.cur.Values = append(e.cur.Values, keyValue{Key: key, Value: v})
+}
+
+func (e *attrEncoder) AddArray(key string, arr zapcore.ArrayMarshaler) error {
+	enc := &arrayEncoder{}
+	err := arr.MarshalLogArray(enc)
+	e.add(key, anyValue{ArrayValue: &arrayValue{Values: enc.values}})
+	return err
+}
+
+func (e *attrEncoder) AddObject(key string, obj zapcore.ObjectMarshaler) error {
+	enc := newAttrEncoder()
+	err := obj.MarshalLogObject(enc)
+	e.add(key, anyValue{KvlistValue: enc.root})
+	return err
+}
+
+func (e *attrEncoder) AddBinary(key string, val []byte) {
+	e.add(key, anyValue{BytesValue: append([]byte(nil), val...)})
+}
+
+func (e *attrEncoder) AddByteString(key string, val []byte) {
+	e.add(key, stringValue(string(val)))
+}
+
+func (e *attrEncoder) AddBool(key string, val bool) { e.add(key, boolValue(val)) }
+
+func (e *attrEncoder) AddComplex128(key string, val complex128) {
+	e.add(key, stringValue(strconv.FormatComplex(val, 'g', -1, 128)))
+}
+
+func (e *attrEncoder) AddComplex64(key string, val complex64) {
+	e.add(key, stringValue(strconv.FormatComplex(complex128(val), 'g', -1, 64)))
+}
+
+func (e *attrEncoder) AddDuration(key string, val time.Duration) {
+	e.add(key, stringValue(val.String()))
+}
+
+func (e *attrEncoder) AddFloat64(key string, val float64) { e.add(key, doubleValue(val)) }
+func (e *attrEncoder) AddFloat32(key string, val float32) { e.add(key, doubleValue(float64(val))) }
+func (e *attrEncoder) AddInt(key string, val int)         { e.add(key, intValue(int64(val))) }
+func (e *attrEncoder) AddInt64(key string, val int64)     { e.add(key, intValue(val)) }
+func (e *attrEncoder) AddInt32(key string, val int32)     { e.add(key, intValue(int64(val))) }
+func (e *attrEncoder) AddInt16(key string, val int16)     { e.add(key, intValue(int64(val))) }
+func (e *attrEncoder) AddInt8(key string, val int8)       { e.add(key, intValue(int64(val))) }
+func (e *attrEncoder) AddString(key, val string)          { e.add(key, stringValue(val)) }
+
+func (e *attrEncoder) AddTime(key string, val time.Time) {
+	e.add(key, stringValue(val.Format(time.RFC3339Nano)))
+}
+
+func (e *attrEncoder) AddUint(key string, val uint)       { e.add(key, uintValue(uint64(val))) }
+func (e *attrEncoder) AddUint64(key string, val uint64)   { e.add(key, uintValue(val)) }
+func (e *attrEncoder) AddUint32(key string, val uint32)   { e.add(key, intValue(int64(val))) }
+func (e *attrEncoder) AddUint16(key string, val uint16)   { e.add(key, intValue(int64(val))) }
+func (e *attrEncoder) AddUint8(key string, val uint8)     { e.add(key, intValue(int64(val))) }
+func (e *attrEncoder) AddUintptr(key string, val uintptr) { e.add(key, uintValue(uint64(val))) }
+
+func (e *attrEncoder) AddReflected(key string, obj interface{}) error {
+	v, err := reflectedValue(obj)
+	if err != nil {
+		return err
+	}
+	e.add(key, v)
+	return nil
+}
+
+func (e *attrEncoder) OpenNamespace(key string) {
+	child := &kvlistValue{}
+	e.add(key, anyValue{KvlistValue: child})
+	e.cur = child
+	e.ns++
+}
+
+// uintValue represents a uint64 as an int if it fits, since OTLP has no
+// unsigned integers.
+func uintValue(u uint64) anyValue {
+	if u > 1<<63-1 {
+		return stringValue(strconv.FormatUint(u, 10))
+	}
+	return intValue(int64(u))
+}
+
+// reflectedValue converts a value to JSON and then to the equivalent
+// AnyValue.
+func reflectedValue(obj interface{}) (anyValue, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return anyValue{}, err
+	}
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return anyValue{}, err
+	}
+	return jsonValue(v), nil
+}
+
+func jsonValue(v interface{}) anyValue {
+	switch v := v.(type) {
+	case nil:
+		return anyValue{}
+	case bool:
+		return boolValue(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return intValue(i)
+		}
+		f, _ := v.Float64()
+		return doubleValue(f)
+	case string:
+		return stringValue(v)
+	case []interface{}:
+		values := make([]anyValue, len(v))
+		for i, elem := range v {
+			values[i] = jsonValue(elem)
+		}
+		return anyValue{ArrayValue: &arrayValue{Values: values}}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		values := make([]keyValue, len(keys))
+		for i, key := range keys {
+			values[i] = keyValue{Key: key, Value: jsonValue(v[key])}
+		}
+		return anyValue{KvlistValue: &kvlistValue{Values: values}}
+	default:
+		return stringValue(fmt.Sprint(v))
+	}
+}
+
+// arrayEncoder is a zapcore.ArrayEncoder that builds an OTLP ArrayValue.
+type arrayEncoder struct {
+	values []anyValue
+}
+
+var _ zapcore.ArrayEncoder = (*arrayEncoder)(nil)
+
+func (e *arrayEncoder) AppendArray(arr zapcore.ArrayMarshaler) error {
+	enc := &arrayEncoder{}
+	err := arr.MarshalLogArray(enc)
+	e.values = append(e.values, anyValue{ArrayValue: &arrayValue{Values: enc.values}})
+	return err
+}
+
+func (e *arrayEncoder) AppendObject(obj zapcore.ObjectMarshaler) error {
+	enc := newAttrEncoder()
+	err := obj.MarshalLogObject(enc)
+	e.values = append(e.values, anyValue{KvlistValue: enc.root})
+	return err
+}
+
+func (e *arrayEncoder) AppendReflected(val interface{}) error {
+	v, err := reflectedValue(val)
+	if err != nil {
+		return err
+	}
+	e.values = append(e.values, v)
+	return nil
+}
+
+func (e *arrayEncoder) AppendBool(v bool) { e.values = append(e.values, boolValue(v)) }
+func (e *arrayEncoder) AppendByteString(v []byte) {
+	e.values = append(e.values, stringValue(string(v)))
+}
+func (e *arrayEncoder) AppendComplex128(v complex128) {
+	e.AppendString(strconv.FormatComplex(v, 'g', -1, 128))
+}
+func (e *arrayEncoder) AppendComplex64(v complex64) {
+	e.AppendString(strconv.FormatComplex(complex128(v), 'g', -1, 64))
+}
+func (e *arrayEncoder) AppendDuration(v time.Duration) { e.AppendString(v.String()) }
+func (e *arrayEncoder) AppendFloat64(v float64)        { e.values = append(e.values, doubleValue(v)) }
+func (e *arrayEncoder) AppendFloat32(v float32)        { e.values = append(e.values, doubleValue(float64(v))) }
+func (e *arrayEncoder) AppendInt(v int)                { e.AppendInt64(int64(v)) }
+func (e *arrayEncoder) AppendInt64(v int64)            { e.values = append(e.values, intValue(v)) }
+func (e *arrayEncoder) AppendInt32(v int32)            { e.AppendInt64(int64(v)) }
+func (e *arrayEncoder) AppendInt16(v int16)            { e.AppendInt64(int64(v)) }
+func (e *arrayEncoder) AppendInt8(v int8)              { e.AppendInt64(int64(v)) }
+func (e *arrayEncoder) AppendString(v string)          { e.values = append(e.values, stringValue(v)) }
+func (e *arrayEncoder) AppendTime(v time.Time)         { e.AppendString(v.Format(time.RFC3339Nano)) }
+func (e *arrayEncoder) AppendUint(v uint)              { e.AppendUint64(uint64(v)) }
+func (e *arrayEncoder) AppendUint64(v uint64)          { e.values = append(e.values, uintValue(v)) }
+func (e *arrayEncoder) AppendUint32(v uint32)          { e.AppendInt64(int64(v)) }
+func (e *arrayEncoder) AppendUint16(v uint16)          { e.AppendInt64(int64(v)) }
+func (e *arrayEncoder) AppendUint8(v uint8)            { e.AppendInt64(int64(v)) }
+func (e *arrayEncoder) AppendUintptr(v uintptr)        { e.AppendUint64(uint64(v)) }
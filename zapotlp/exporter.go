@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapotlp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// An Exporter sends batches of log records to their destination.
+type Exporter interface {
+	// Export sends an OTLP/JSON-encoded ExportLogsServiceRequest.
+	Export(ctx context.Context, request []byte) error
+}
+
+// NewWriterExporter creates an Exporter that writes each request to ws as a
+// line of JSON, like the OpenTelemetry Collector's file exporter. The
+// output can be replayed to a collector with its otlpjsonfile receiver.
+func NewWriterExporter(ws zapcore.WriteSyncer) Exporter {
+	return &writerExporter{ws: ws}
+}
+
+type writerExporter struct {
+	ws zapcore.WriteSyncer
+}
+
+func (e *writerExporter) Export(_ context.Context, request []byte) error {
+	line := make([]byte, 0, len(request)+1)
+	line = append(line, request...)
+	line = append(line, '\n')
+	_, err := e.ws.Write(line)
+	return err
+}
+
+func (e *writerExporter) Sync() error {
+	return e.ws.Sync()
+}
+
+// An HTTPOption configures an Exporter created with NewHTTPExporter.
+type HTTPOption interface {
+	apply(*httpExporter)
+}
+
+type httpOptionFunc func(*httpExporter)
+
+func (f httpOptionFunc) apply(e *httpExporter) {
+	f(e)
+}
+
+// WithHTTPClient sets the client used to send requests. It defaults to a
+// client with a 10 second timeout.
+func WithHTTPClient(client *http.Client) HTTPOption {
+	return httpOptionFunc(func(e *httpExporter) {
+		e.client = client
+	})
+}
+
+// WithHTTPHeaders adds headers to every request, such as those needed to
+// authenticate with a collector.
+func WithHTTPHeaders(headers map[string]string) HTTPOption {
+	return httpOptionFunc(func(e *httpExporter) {
+		for k, v := range headers {
+			e.headers.Set(k, v)
+		}
+	})
+}
+
+// NewHTTPExporter creates an Exporter that sends requests to an OTLP/HTTP
+// endpoint using the JSON encoding, such as
+// "http://localhost:4318/v1/logs" for a local OpenTelemetry Collector.
+//
+// The Exporter doesn't retry requests itself. A Core keeps the records of a
+// failed request and retries them with its next export.
+func NewHTTPExporter(endpoint string, opts ...HTTPOption) Exporter {
+	e := &httpExporter{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		headers:  make(http.Header),
+	}
+	for _, opt := range opts {
+		opt.apply(e)
+	}
+	return e
+}
+
+type httpExporter struct {
+	endpoint string
+	client   *http.Client
+	headers  http.Header
+}
+
+func (e *httpExporter) Export(ctx context.Context, request []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(request))
+	if err != nil {
+		return err
+	}
+	for k, v := range e.headers {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("export logs to %v: %v: %s", e.endpoint, res.Status, bytes.TrimSpace(body))
+	}
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, res.Body)
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package zapotlp
+
+import (
+	"encoding/hex"
+	"math"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// The types below mirror the OTLP/JSON encoding of the messages in
+// opentelemetry/proto/logs/v1/logs.proto and
+// opentelemetry/proto/collector/logs/v1/logs_service.proto.
+
+type exportLogsServiceRequest struct {
+	ResourceLogs []resourceLogs `json:"resourceLogs"`
+}
+
+type resourceLogs struct {
+	Resource  resource    `json:"resource"`
+	ScopeLogs []scopeLogs `json:"scopeLogs"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes,omitempty"`
+}
+
+type scopeLogs struct {
+	Scope      scope       `json:"scope"`
+	LogRecords []logRecord `json:"logRecords"`
+}
+
+type scope struct {
+	Name string `json:"name,omitempty"`
+}
+
+type logRecord struct {
+	TimeUnixNano         uint64     `json:"timeUnixNano,string"`
+	ObservedTimeUnixNano uint64     `json:"observedTimeUnixNano,string"`
+	SeverityNumber       int        `json:"severityNumber"`
+	SeverityText         string     `json:"severityText"`
+	Body                 anyValue   `json:"body"`
+	Attributes           []keyValue `json:"attributes,omitempty"`
+	TraceID              string     `json:"traceId,omitempty"`
+	SpanID               string     `json:"spanId,omitempty"`
+	Flags                uint32     `json:"flags,omitempty"`
+
+	scope string // instrumentation scope; not part of the record
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+// anyValue holds exactly one of its fields, or none for an empty value.
+type anyValue struct {
+	StringValue *string      `json:"stringValue,omitempty"`
+	BoolValue   *bool        `json:"boolValue,omitempty"`
+	IntValue    *int64       `json:"intValue,omitempty,string"`
+	DoubleValue *float64     `json:"doubleValue,omitempty"`
+	BytesValue  []byte       `json:"bytesValue,omitempty"`
+	ArrayValue  *arrayValue  `json:"arrayValue,omitempty"`
+	KvlistValue *kvlistValue `json:"kvlistValue,omitempty"`
+}
+
+type arrayValue struct {
+	Values []anyValue `json:"values"`
+}
+
+type kvlistValue struct {
+	Values []keyValue `json:"values"`
+}
+
+func stringValue(s string) anyValue { return anyValue{StringValue: &s} }
+func boolValue(b bool) anyValue     { return anyValue{BoolValue: &b} }
+func intValue(i int64) anyValue     { return anyValue{IntValue: &i} }
+
+func doubleValue(f float64) anyValue {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		// JSON has no notation for these.
+		return stringValue(formatSpecialFloat(f))
+	}
+	return anyValue{DoubleValue: &f}
+}
+
+func formatSpecialFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "+Inf"
+	default:
+		return "-Inf"
+	}
+}
+
+// Severity numbers defined by the OpenTelemetry logs data model.
+const (
+	severityDebug  = 5
+	severityInfo   = 9
+	severityWarn   = 13
+	severityError  = 17
+	severityError2 = 18
+	severityFatal  = 21
+	severityFatal2 = 22
+)
+
+// severityNumber maps a zap Level to an OpenTelemetry SeverityNumber.
+// DPanic, Panic, and Fatal are distinguished within the ERROR and FATAL
+// ranges.
+func severityNumber(lvl zapcore.Level) int {
+	switch lvl {
+	case zapcore.DebugLevel:
+		return severityDebug
+	case zapcore.InfoLevel:
+		return severityInfo
+	case zapcore.WarnLevel:
+		return severityWarn
+	case zapcore.ErrorLevel:
+		return severityError
+	case zapcore.DPanicLevel:
+		return severityError2
+	case zapcore.PanicLevel:
+		return severityFatal
+	case zapcore.FatalLevel:
+		return severityFatal2
+	default:
+		if lvl < zapcore.DebugLevel {
+			return severityDebug
+		}
+		return severityFatal2
+	}
+}
+
+// isHexID reports whether s is a lowercase hex encoding of n bytes that
+// aren't all zero, as required of trace and span IDs.
+func isHexID(s string, n int) bool {
+	if len(s) != 2*n {
+		return false
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return false
+	}
+	for _, c := range b {
+		if c != 0 {
+			return true
+		}
+	}
+	return false
+}